@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Changelog struct{}
+
+func NewChangelog() *Changelog {
+	return &Changelog{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Changelog) Signature() string {
+	return "changelog"
+}
+
+// Description The console command description.
+func (r *Changelog) Description() string {
+	return "Aggregate the release notes of all repos into one changelog"
+}
+
+// Extend The console command extend.
+func (r *Changelog) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The released tag to aggregate the release notes of, e.g. v1.17.0",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "The path to save the changelog, default storage/release/changelog-<tag>.md",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Changelog) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Changelog())
+}
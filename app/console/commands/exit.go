@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"errors"
+)
+
+// The exit codes of the failure categories, so a workflow running the commands can tell why a release stopped.
+const (
+	// ExitCodeFailure an unexpected failure, e.g. a GitHub request or a git command failed
+	ExitCodeFailure = 1
+	// ExitCodeInvalidInput an invalid tag, flag, manifest or release plan
+	ExitCodeInvalidInput = 2
+	// ExitCodeRefused a confirmation is declined or the release is refused by a policy, e.g. a suggested version
+	ExitCodeRefused = 3
+	// ExitCodeChecksFailed the tests in the sub-packages or the CI checks failed
+	ExitCodeChecksFailed = 4
+	// ExitCodeIncompatibleAPI incompatible API changes are found in a patch release
+	ExitCodeIncompatibleAPI = 5
+	// ExitCodePending the checks or the PRs are still pending, e.g. the PRs are not merged before the merge timeout
+	ExitCodePending = 6
+)
+
+// ExitError is an error of a failure category, the command exits with its code. It implements the exit coder of
+// the command line, so the process exits with the code once the command returns an ExitError from Handle.
+type ExitError struct {
+	// The exit code of the failure category
+	Code int
+	// The error
+	Err error
+}
+
+func (r *ExitError) Error() string {
+	return r.Err.Error()
+}
+
+// ExitCode returns the exit code of the failure category.
+func (r *ExitError) ExitCode() int {
+	return r.Code
+}
+
+func (r *ExitError) Unwrap() error {
+	return r.Err
+}
+
+// withExitCode categorizes the error, it returns nil if the error is nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ExitError{Code: code, Err: err}
+}
+
+// ExitCode returns the exit code of the error: 0 if it's nil, the code of the first categorized error it wraps,
+// or ExitCodeFailure if it isn't categorized.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	return ExitCodeFailure
+}
+
+// exitError categorizes the error returned by a command, it returns nil if the error is nil. The error is returned
+// from Handle instead of exiting in place, so the command line prints it and exits with its code only after the
+// command and its deferred cleanup are done.
+func exitError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// The command line reads the exit code from the returned error itself, not from the errors it wraps.
+	if exitErr, ok := err.(*ExitError); ok {
+		return exitErr
+	}
+
+	return &ExitError{Code: ExitCode(err), Err: err}
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "nil",
+			want: 0,
+		},
+		{
+			name: "not categorized",
+			err:  errors.New("failed to clone framework"),
+			want: ExitCodeFailure,
+		},
+		{
+			name: "categorized",
+			err:  withExitCode(ExitCodeInvalidInput, errors.New(`invalid tag "v1.17"`)),
+			want: ExitCodeInvalidInput,
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("failed to check upgrade PRs merge status: %w", withExitCode(ExitCodePending, errors.New("not merged"))),
+			want: ExitCodePending,
+		},
+		{
+			name: "joined, the first categorized error decides",
+			err: errors.Join(
+				errors.New("failed to merge"),
+				withExitCode(ExitCodeChecksFailed, errors.New("the checks are failing")),
+				withExitCode(ExitCodePending, errors.New("not merged")),
+			),
+			want: ExitCodeChecksFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+
+	assert.Nil(t, withExitCode(ExitCodeRefused, nil))
+}
+
+func TestExitError(t *testing.T) {
+	assert.Nil(t, exitError(nil))
+
+	categorized := withExitCode(ExitCodeIncompatibleAPI, errors.New("incompatible API changes found in framework"))
+	assert.Same(t, categorized, exitError(categorized))
+
+	wrapped := fmt.Errorf("failed to check upgrade PRs merge status: %w", withExitCode(ExitCodePending, errors.New("not merged")))
+	err := exitError(wrapped)
+	assert.EqualError(t, err, wrapped.Error())
+	assert.ErrorIs(t, err, wrapped)
+
+	// The command line exits with the code of the error returned by Handle, see cli.ExitCoder.
+	var exitCoder interface{ ExitCode() int }
+	require.ErrorAs(t, err, &exitCoder)
+	assert.Equal(t, ExitCodePending, exitCoder.ExitCode())
+
+	err = exitError(errors.New("failed to clone framework"))
+	require.ErrorAs(t, err, &exitCoder)
+	assert.Equal(t, ExitCodeFailure, exitCoder.ExitCode())
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// DependencyGraph is the dependency graph of the manifest repos, the dependencies come from
+// the manifest and the requires in each repo's go.mod.
+type DependencyGraph struct {
+	manifest *Manifest
+	// The dependencies of each repo, keyed by repo
+	dependencies map[string][]string
+}
+
+func NewDependencyGraph(manifest *Manifest) *DependencyGraph {
+	graph := &DependencyGraph{
+		manifest:     manifest,
+		dependencies: make(map[string][]string),
+	}
+
+	for _, repo := range manifest.Repos {
+		for _, dependency := range repo.Dependencies {
+			graph.addDependency(repo.Name, dependency)
+		}
+	}
+
+	return graph
+}
+
+// AddGoMod adds the manifest repos required by the go.mod of the repo as its dependencies.
+func (r *DependencyGraph) AddGoMod(repo string, content []byte) error {
+	file, err := modfile.ParseLax(repo+"/go.mod", content, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod of %s: %w", repo, err)
+	}
+
+	for _, require := range file.Require {
+		for _, dependency := range r.manifest.Repos {
+			if dependency.Name != repo && dependency.Module() == require.Mod.Path {
+				r.addDependency(repo, dependency.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dependencies returns the dependencies of the repo, in the order of the manifest.
+func (r *DependencyGraph) Dependencies(repo string) []string {
+	return r.dependencies[repo]
+}
+
+// Levels sorts the repos topologically, the repos in a level only depend on the repos in the previous levels,
+// so they can be released once the previous levels are released. A dependency cycle is an error.
+func (r *DependencyGraph) Levels() ([][]*Repo, error) {
+	var (
+		levels   [][]*Repo
+		released = make(map[string]bool)
+	)
+
+	for len(released) < len(r.manifest.Repos) {
+		var level []*Repo
+		for _, repo := range r.manifest.Repos {
+			if released[repo.Name] {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range r.dependencies[repo.Name] {
+				if !released[dependency] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				level = append(level, repo)
+			}
+		}
+
+		if len(level) == 0 {
+			var cycle []string
+			for _, repo := range r.manifest.Repos {
+				if !released[repo.Name] {
+					cycle = append(cycle, repo.Name)
+				}
+			}
+
+			return nil, fmt.Errorf("dependency cycle detected among: %s", strings.Join(cycle, ", "))
+		}
+
+		for _, repo := range level {
+			released[repo.Name] = true
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+func (r *DependencyGraph) addDependency(repo, dependency string) {
+	if slices.Contains(r.dependencies[repo], dependency) {
+		return
+	}
+
+	r.dependencies[repo] = append(r.dependencies[repo], dependency)
+	slices.SortStableFunc(r.dependencies[repo], func(a, b string) int {
+		return r.index(a) - r.index(b)
+	})
+}
+
+func (r *DependencyGraph) index(repo string) int {
+	return slices.IndexFunc(r.manifest.Repos, func(item *Repo) bool {
+		return item.Name == repo
+	})
+}
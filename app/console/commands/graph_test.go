@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	manifest := newTestManifest(
+		&Repo{Name: "framework", Role: RoleFramework},
+		&Repo{Name: "gin", Role: RolePackage},
+		&Repo{Name: "postgres", Role: RolePackage},
+		&Repo{Name: "goravel-lite", Role: RoleApp},
+		&Repo{Name: "example", Role: RoleApp, Dependencies: []string{"framework"}},
+	)
+
+	graph := NewDependencyGraph(manifest)
+	require.NoError(t, graph.AddGoMod("gin", []byte(`module github.com/goravel/gin
+
+go 1.24
+
+require (
+	github.com/gin-gonic/gin v1.10.0
+	github.com/goravel/framework v1.16.0
+)`)))
+	require.NoError(t, graph.AddGoMod("postgres", []byte(`module github.com/goravel/postgres
+
+require github.com/goravel/framework v1.16.0`)))
+	require.NoError(t, graph.AddGoMod("goravel-lite", []byte(`module goravel
+
+require github.com/goravel/framework v1.16.0`)))
+	require.NoError(t, graph.AddGoMod("example", []byte(`module goravel
+
+require (
+	github.com/goravel/postgres v1.4.0
+	github.com/goravel/gin v1.4.0
+	github.com/goravel/framework v1.16.0
+	github.com/goravel/unknown v1.0.0
+)`)))
+
+	assert.Equal(t, []string{"framework", "gin", "postgres"}, graph.Dependencies("example"))
+	assert.Equal(t, []string{"framework"}, graph.Dependencies("gin"))
+	assert.Nil(t, graph.Dependencies("framework"))
+
+	levels, err := graph.Levels()
+	require.NoError(t, err)
+	require.Len(t, levels, 3)
+	assert.Equal(t, []string{"framework"}, repoNames(levels[0]))
+	assert.Equal(t, []string{"gin", "postgres", "goravel-lite"}, repoNames(levels[1]))
+	assert.Equal(t, []string{"example"}, repoNames(levels[2]))
+
+	assert.ErrorContains(t, graph.AddGoMod("gin", []byte("require (")), "failed to parse go.mod of gin")
+}
+
+func TestDependencyGraphCycle(t *testing.T) {
+	manifest := newTestManifest(
+		&Repo{Name: "framework", Role: RoleFramework},
+		&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework", "fiber"}},
+		&Repo{Name: "fiber", Role: RolePackage, Dependencies: []string{"gin"}},
+	)
+
+	levels, err := NewDependencyGraph(manifest).Levels()
+
+	assert.Nil(t, levels)
+	assert.EqualError(t, err, "dependency cycle detected among: gin, fiber")
+}
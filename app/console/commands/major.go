@@ -28,6 +28,7 @@ func (r *Major) Extend() command.Extend {
 		Arguments: []command.Argument{
 			&command.ArgumentString{
 				Name:     "tag",
+				Usage:    "The tag to release, e.g. v1.16.0, or auto to suggest it from the PRs merged into master",
 				Required: true,
 			},
 		},
@@ -42,18 +43,65 @@ func (r *Major) Extend() command.Extend {
 				Aliases: []string{},
 				Usage:   "Refresh Go module proxy cache before release",
 			},
+			&command.BoolFlag{
+				Name:    "resume",
+				Aliases: []string{},
+				Usage:   "Resume the release from the first unfinished step of the previous run",
+			},
+			&command.IntFlag{
+				Name:    "concurrency",
+				Aliases: []string{"c"},
+				Value:   4,
+				Usage:   "The max number of repos to upgrade or test at the same time",
+			},
+			&command.StringFlag{
+				Name:    "plan",
+				Aliases: []string{},
+				Usage:   "The path to save the release plan in preview mode, default storage/release/<command>-<tag>-plan.json",
+			},
 			&command.StringFlag{
 				Name:    "framework-branch",
 				Aliases: []string{"fb"},
 				Usage:   "Optional, release framework branch, sometimes go mod cannot fetch the latest master",
 			},
+			&command.IntFlag{
+				Name:    "wait-checks",
+				Aliases: []string{},
+				Value:   30,
+				Usage:   "The minutes to wait for the pending checks at the release branch, e.g. the checks triggered by the merged PRs, 0 fails on pending checks",
+			},
+			&command.BoolFlag{
+				Name:    "auto-merge",
+				Aliases: []string{},
+				Usage:   "Merge the upgrade and version PRs once their checks pass instead of asking to check the merge status",
+			},
+			&command.StringFlag{
+				Name:    "merge-method",
+				Aliases: []string{},
+				Value:   "squash",
+				Usage:   "The method to merge the PRs with --auto-merge: merge, squash or rebase",
+			},
+			&command.IntFlag{
+				Name:    "merge-timeout",
+				Aliases: []string{},
+				Value:   60,
+				Usage:   "The minutes to wait for the PRs to be merged with --auto-merge",
+			},
+			&command.BoolFlag{
+				Name:    "non-interactive",
+				Aliases: []string{"yes", "y"},
+				Usage:   "Answer every question with its policy answer instead of asking, e.g. in a CI workflow",
+			},
 		},
 	}
 }
 
 // Handle Execute the console command.
 func (r *Major) Handle(ctx console.Context) error {
-	release := NewRelease(ctx)
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
 
-	return release.Major()
+	return exitError(release.Major())
 }
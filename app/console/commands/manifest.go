@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+type RepoRole string
+
+const (
+	// RoleFramework the goravel/framework repo, it's released first.
+	RoleFramework RepoRole = "framework"
+	// RolePackage the driver packages and tools that depend on the framework.
+	RolePackage RepoRole = "package"
+	// RoleApp the starter applications.
+	RoleApp RepoRole = "app"
+)
+
+// Manifest declares all repositories that take part in a release. It's loaded from a YAML file,
+// JSON is accepted as well given it's a subset of YAML.
+type Manifest struct {
+	// The default owner of the repos
+	Owner string `yaml:"owner"`
+	// The repos, the repos in the same dependency level are released in this order
+	Repos []*Repo `yaml:"repos"`
+}
+
+type Repo struct {
+	// The repo name
+	Name string `yaml:"name"`
+	// The repo owner, the manifest owner will be used if it's empty
+	Owner string `yaml:"owner"`
+	// The repo role: framework, package or app
+	Role RepoRole `yaml:"role"`
+	// The repos in the manifest this repo depends on besides its go.mod requires, they will be upgraded via `go get`
+	Dependencies []string `yaml:"dependencies"`
+	// Whether to push the version branch, e.g. v1.16.x, when releasing a major version
+	Branch bool `yaml:"branch"`
+	// Whether to set the version branch as the default branch when releasing a major version
+	DefaultBranch bool `yaml:"default_branch"`
+	// Whether the repo is upgraded by its own workflow instead of an upgrade PR created by this tool
+	AutoUpgrade bool `yaml:"auto_upgrade"`
+	// Whether to skip creating GitHub releases for the repo
+	SkipRelease bool `yaml:"skip_release"`
+	// The file contains the Version constant, e.g. support/constant.go
+	VersionFile string `yaml:"version_file"`
+}
+
+func repoNames(repos []*Repo) []string {
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+
+	return names
+}
+
+func LoadManifest(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release manifest %s: %w", path, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest %s: %w", path, err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("invalid release manifest %s: %w", path, err)
+	}
+
+	return &manifest, nil
+}
+
+// Module returns the Go module path of the repo.
+func (r *Repo) Module() string {
+	return fmt.Sprintf("github.com/%s/%s", r.Owner, r.Name)
+}
+
+// AutoUpgrades returns the repos that are upgraded by their own workflows.
+func (r *Manifest) AutoUpgrades() []*Repo {
+	return r.filter(func(repo *Repo) bool {
+		return repo.AutoUpgrade
+	})
+}
+
+// Apps returns the starter applications that are upgraded by this tool.
+func (r *Manifest) Apps() []*Repo {
+	return r.filter(func(repo *Repo) bool {
+		return repo.Role == RoleApp && !repo.AutoUpgrade
+	})
+}
+
+// Dependents returns the repos that are upgraded by this tool and depend on other repos besides the framework,
+// they can only be upgraded after the packages are released.
+func (r *Manifest) Dependents() []*Repo {
+	return r.filter(func(repo *Repo) bool {
+		return repo.Role != RoleFramework && !repo.AutoUpgrade && !r.dependsOnFrameworkOnly(repo)
+	})
+}
+
+func (r *Manifest) Framework() *Repo {
+	for _, repo := range r.Repos {
+		if repo.Role == RoleFramework {
+			return repo
+		}
+	}
+
+	return nil
+}
+
+// RepoOwner returns the owner of the repo, the default owner will be returned if the repo is not in the manifest.
+func (r *Manifest) RepoOwner(name string) string {
+	if repo := r.Repo(name); repo != nil {
+		return repo.Owner
+	}
+
+	return r.Owner
+}
+
+// Packages returns the repos that are upgraded by this tool and depend on the framework only,
+// they can be upgraded right after the framework is released.
+func (r *Manifest) Packages() []*Repo {
+	return r.filter(func(repo *Repo) bool {
+		return repo.Role != RoleFramework && !repo.AutoUpgrade && r.dependsOnFrameworkOnly(repo)
+	})
+}
+
+// Releasable returns the repos whose release information can be got before the release starts,
+// the auto upgrade repos are excluded given they can only be released after they are upgraded.
+func (r *Manifest) Releasable() []*Repo {
+	return r.filter(func(repo *Repo) bool {
+		return !repo.AutoUpgrade && !repo.SkipRelease
+	})
+}
+
+func (r *Manifest) Repo(name string) *Repo {
+	for _, repo := range r.Repos {
+		if repo.Name == name {
+			return repo
+		}
+	}
+
+	return nil
+}
+
+func (r *Manifest) dependsOnFrameworkOnly(repo *Repo) bool {
+	for _, dependency := range repo.Dependencies {
+		if dependencyRepo := r.Repo(dependency); dependencyRepo == nil || dependencyRepo.Role != RoleFramework {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Manifest) filter(match func(repo *Repo) bool) []*Repo {
+	var repos []*Repo
+	for _, repo := range r.Repos {
+		if match(repo) {
+			repos = append(repos, repo)
+		}
+	}
+
+	return repos
+}
+
+func (r *Manifest) validate() error {
+	var (
+		names     []string
+		framework int
+	)
+
+	for _, repo := range r.Repos {
+		if repo.Name == "" {
+			return fmt.Errorf("repo name is required")
+		}
+		if slices.Contains(names, repo.Name) {
+			return fmt.Errorf("repo %s is declared more than once", repo.Name)
+		}
+		names = append(names, repo.Name)
+
+		if repo.Owner == "" {
+			repo.Owner = r.Owner
+		}
+		if repo.Owner == "" {
+			return fmt.Errorf("owner of repo %s is required", repo.Name)
+		}
+
+		switch repo.Role {
+		case RoleFramework:
+			framework++
+		case RolePackage, RoleApp:
+		default:
+			return fmt.Errorf("role %q of repo %s is invalid, it should be one of framework, package and app", repo.Role, repo.Name)
+		}
+	}
+
+	if framework != 1 {
+		return fmt.Errorf("exactly one repo with the framework role is required")
+	}
+
+	for _, repo := range r.Repos {
+		for _, dependency := range repo.Dependencies {
+			if !slices.Contains(names, dependency) {
+				return fmt.Errorf("dependency %s of repo %s is not declared", dependency, repo.Name)
+			}
+			if dependency == repo.Name {
+				return fmt.Errorf("repo %s depends on itself", repo.Name)
+			}
+		}
+	}
+
+	return nil
+}
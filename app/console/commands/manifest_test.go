@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifest(t *testing.T) {
+	manifest, err := LoadManifest("../../../release.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "framework", manifest.Framework().Name)
+	assert.Equal(t, []string{"gin", "fiber", "s3", "oss", "cos", "minio", "postgres", "mysql", "sqlserver", "sqlite", "redis", "installer", "goravel-lite"}, repoNames(manifest.Packages()))
+	assert.Equal(t, []string{"example"}, repoNames(manifest.Dependents()))
+	assert.Equal(t, []string{"goravel"}, repoNames(manifest.AutoUpgrades()))
+	assert.Equal(t, []string{"goravel-lite", "example"}, repoNames(manifest.Apps()))
+	assert.NotContains(t, repoNames(manifest.Releasable()), "example")
+	assert.NotContains(t, repoNames(manifest.Releasable()), "goravel")
+	assert.Equal(t, "goravel", manifest.RepoOwner("gin"))
+	assert.Equal(t, "github.com/goravel/gin", manifest.Repo("gin").Module())
+}
+
+func TestLoadManifestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "json is supported",
+			content: `{"owner": "goravel", "repos": [{"name": "framework", "role": "framework"}, {"name": "gin", "owner": "other", "role": "package", "dependencies": ["framework"]}]}`,
+		},
+		{
+			name: "missing owner",
+			content: `repos:
+  - name: framework
+    role: framework`,
+			wantErr: "owner of repo framework is required",
+		},
+		{
+			name: "duplicated repo",
+			content: `owner: goravel
+repos:
+  - name: framework
+    role: framework
+  - name: framework
+    role: package`,
+			wantErr: "repo framework is declared more than once",
+		},
+		{
+			name: "invalid role",
+			content: `owner: goravel
+repos:
+  - name: framework
+    role: core`,
+			wantErr: `role "core" of repo framework is invalid, it should be one of framework, package and app`,
+		},
+		{
+			name: "missing framework",
+			content: `owner: goravel
+repos:
+  - name: gin
+    role: package`,
+			wantErr: "exactly one repo with the framework role is required",
+		},
+		{
+			name: "undeclared dependency",
+			content: `owner: goravel
+repos:
+  - name: framework
+    role: framework
+  - name: gin
+    role: package
+    dependencies: [fiber]`,
+			wantErr: "dependency fiber of repo gin is not declared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "release.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			manifest, err := LoadManifest(path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, "invalid release manifest "+path+": "+tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "other", manifest.RepoOwner("gin"))
+			assert.Equal(t, "goravel", manifest.RepoOwner("framework"))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Next struct{}
+
+func NewNext() *Next {
+	return &Next{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Next) Signature() string {
+	return "next"
+}
+
+// Description The console command description.
+func (r *Next) Description() string {
+	return "Suggest the next version from the PRs merged since the latest releases"
+}
+
+// Extend The console command extend.
+func (r *Next) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "patch",
+				Aliases: []string{"p"},
+				Usage:   "Suggest a patch version from the PRs merged into the version branches",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Next) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Next())
+}
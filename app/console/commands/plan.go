@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Plan struct{}
+
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Plan) Signature() string {
+	return "plan"
+}
+
+// Description The console command description.
+func (r *Plan) Description() string {
+	return "Resolve the release information and save it as a release plan"
+}
+
+// Extend The console command extend.
+func (r *Plan) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The tag to plan, or auto to suggest it from the merged PRs",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "The path to save the release plan, default storage/release/<command>-<tag>-release-plan.json",
+			},
+			&command.BoolFlag{
+				Name:    "patch",
+				Aliases: []string{"p"},
+				Usage:   "Plan a patch release instead of a major release",
+			},
+			&command.BoolFlag{
+				Name:    "allow-breaking",
+				Aliases: []string{},
+				Usage:   "Plan the patch release even if incompatible API changes are found against the previous tag",
+			},
+			&command.BoolFlag{
+				Name:    "non-interactive",
+				Aliases: []string{"yes", "y"},
+				Usage:   "Answer every question with its policy answer instead of asking, e.g. in a CI workflow",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Plan) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Plan())
+}
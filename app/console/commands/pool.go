@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// RepoTask is a task run against a repo in the worker pool, it works in its own temp directory
+// and writes its output to output, so the output of concurrent tasks is not interleaved.
+type RepoTask func(repo, dir string, output io.Writer) error
+
+type RepoResult struct {
+	// The repo name
+	Repo string
+	// The aggregated output of the task
+	Output string
+	// The error returned by the task, the task passed if it's nil
+	Err error
+}
+
+// runInPool runs the task against the repos with at most concurrency workers, every task gets an isolated
+// temp directory that is removed once the task is done. The results are returned in the order of the repos.
+func runInPool(concurrency int, repos []string, task RepoTask) []*RepoResult {
+	concurrency = max(1, min(concurrency, len(repos)))
+
+	var (
+		results = make([]*RepoResult, len(repos))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				results[index] = runRepoTask(repos[index], task)
+			}
+		}()
+	}
+
+	for index := range repos {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// poolError joins the errors of the failed results, it returns nil if all tasks passed.
+func poolError(results []*RepoResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// makeRepoDir creates an isolated temp directory to clone the repo into, the caller should remove it once done.
+func makeRepoDir(repo string) (string, error) {
+	dir, err := os.MkdirTemp("", "goravel-release-"+repo+"-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory for %s: %w", repo, err)
+	}
+
+	return dir, nil
+}
+
+func runRepoTask(repo string, task RepoTask) *RepoResult {
+	var (
+		output repoOutput
+		result = &RepoResult{Repo: repo}
+	)
+
+	dir, err := makeRepoDir(repo)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	result.Err = task(repo, dir, &output)
+	result.Output = output.String()
+
+	return result
+}
+
+// repoOutput is the aggregated output of a task, it's safe for concurrent writes given
+// the stdout and stderr of a process are copied in different goroutines.
+type repoOutput struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (r *repoOutput) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buffer.Write(p)
+}
+
+func (r *repoOutput) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buffer.String()
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunInPool(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		dirs    = make(map[string]string)
+		running atomic.Int32
+		peak    atomic.Int32
+		repos   = []string{"framework", "gin", "fiber", "s3", "oss"}
+	)
+
+	results := runInPool(2, repos, func(repo, dir string, output io.Writer) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		mu.Lock()
+		dirs[repo] = dir
+		mu.Unlock()
+
+		assert.DirExists(t, dir)
+		_, _ = fmt.Fprintf(output, "testing %s\n", repo)
+		time.Sleep(10 * time.Millisecond)
+
+		if repo == "fiber" {
+			return errors.New("failed to test in fiber")
+		}
+
+		return nil
+	})
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Len(t, results, len(repos))
+	for i, result := range results {
+		assert.Equal(t, repos[i], result.Repo)
+		assert.Equal(t, fmt.Sprintf("testing %s\n", result.Repo), result.Output)
+		assert.NoDirExists(t, dirs[result.Repo])
+	}
+	assert.Len(t, dirs, len(repos))
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[2].Err, "failed to test in fiber")
+	assert.EqualError(t, poolError(results), "failed to test in fiber")
+
+	results = runInPool(0, []string{"framework"}, func(repo, dir string, output io.Writer) error {
+		_, err := os.Stat(dir)
+
+		return err
+	})
+	assert.NoError(t, poolError(results))
+}
@@ -44,7 +44,10 @@ func (r *Preview) Extend() command.Extend {
 
 // Handle Execute the console command.
 func (r *Preview) Handle(ctx console.Context) error {
-	release := NewRelease(ctx)
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
 
-	return release.Preview()
+	return exitError(release.Preview())
 }
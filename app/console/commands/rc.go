@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type RC struct{}
+
+func NewRC() *RC {
+	return &RC{}
+}
+
+// Signature The name and signature of the console command.
+func (r *RC) Signature() string {
+	return "rc"
+}
+
+// Description The console command description.
+func (r *RC) Description() string {
+	return "Release prerelease version, e.g. v1.17.0-rc.1"
+}
+
+// Extend The console command extend.
+func (r *RC) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The prerelease tag to release, e.g. v1.17.0-rc.1",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "real",
+				Aliases: []string{"r"},
+				Usage:   "Real release",
+			},
+			&command.BoolFlag{
+				Name:    "refresh",
+				Aliases: []string{},
+				Usage:   "Refresh Go module proxy cache before release",
+			},
+			&command.BoolFlag{
+				Name:    "resume",
+				Aliases: []string{},
+				Usage:   "Resume the release from the first unfinished step of the previous run",
+			},
+			&command.IntFlag{
+				Name:    "concurrency",
+				Aliases: []string{"c"},
+				Value:   4,
+				Usage:   "The max number of repos to upgrade or test at the same time",
+			},
+			&command.StringFlag{
+				Name:    "plan",
+				Aliases: []string{},
+				Usage:   "The path to save the release plan in preview mode, default storage/release/<command>-<tag>-plan.json",
+			},
+			&command.IntFlag{
+				Name:    "wait-checks",
+				Aliases: []string{},
+				Value:   30,
+				Usage:   "The minutes to wait for the pending checks at the release branch, e.g. the checks triggered by the merged PRs, 0 fails on pending checks",
+			},
+			&command.BoolFlag{
+				Name:    "auto-merge",
+				Aliases: []string{},
+				Usage:   "Merge the upgrade and version PRs once their checks pass instead of asking to check the merge status",
+			},
+			&command.StringFlag{
+				Name:    "merge-method",
+				Aliases: []string{},
+				Value:   "squash",
+				Usage:   "The method to merge the PRs with --auto-merge: merge, squash or rebase",
+			},
+			&command.IntFlag{
+				Name:    "merge-timeout",
+				Aliases: []string{},
+				Value:   60,
+				Usage:   "The minutes to wait for the PRs to be merged with --auto-merge",
+			},
+			&command.BoolFlag{
+				Name:    "non-interactive",
+				Aliases: []string{"yes", "y"},
+				Usage:   "Answer every question with its policy answer instead of asking, e.g. in a CI workflow",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *RC) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.RC())
+}
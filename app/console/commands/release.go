@@ -1,12 +1,21 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v84/github"
 	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/process"
 	"github.com/goravel/framework/support/color"
 	"github.com/goravel/framework/support/convert"
 
@@ -14,29 +23,42 @@ import (
 	"goravel/app/services"
 )
 
-const owner = "goravel"
+// defaultCloneURL clones the repos from GitHub via SSH.
+const defaultCloneURL = "git@github.com:%s/%s.git"
 
-var packages = []string{
-	"gin",
-	"fiber",
-	"s3",
-	"oss",
-	"cos",
-	"minio",
-	"postgres",
-	"mysql",
-	"sqlserver",
-	"sqlite",
-	"redis",
-	"installer",
-	"goravel-lite",
-}
+// defaultPollInterval is the interval to poll GitHub while waiting, e.g. for the pending checks.
+const defaultPollInterval = 30 * time.Second
+
+// maxPollInterval caps the backoff of the interval while waiting for the PRs to be merged automatically.
+const maxPollInterval = 5 * time.Minute
+
+// noChecksGracePeriod is the time for the checks to be reported after a PR is created, a PR without any check
+// reported after it, and without any check required by its base branch, is merged as the repo has no CI.
+const noChecksGracePeriod = 5 * time.Minute
+
+// defaultMergeTimeout is the time to wait for the PRs to be merged automatically if --merge-timeout isn't set.
+const defaultMergeTimeout = time.Hour
+
+// defaultVerifyRetries is the number of attempts to fetch the released modules if --retries isn't set.
+const defaultVerifyRetries = 5
+
+// mergeMethods are the merge methods supported by GitHub.
+var mergeMethods = []string{"merge", "squash", "rebase"}
+
+// versionRegexp matches the Version constant in the version file, e.g. const Version string = "v1.16.0".
+var versionRegexp = regexp.MustCompile(`Version\s*.*?=\s*"([^"]+)"`)
 
 type ReleaseInformation struct {
-	// The current tag in master, only goravel/framework and goravel/installer has this tag currently.
+	// The branch the release targets
+	branch string
+	// The current tag in code, only the repos declaring version_file in the manifest have this tag.
 	currentTag string
+	// The incompatible API changes against the latest tag, only the framework and the packages are checked
+	incompatibleChanges []string
 	// The latest tag actually
 	latestTag string
+	// How the latest tag is resolved, it's empty unless the choice is ambiguous
+	latestTagDecisions []string
 	// The release notes
 	notes *github.RepositoryReleaseNotes
 	// The repo name
@@ -45,150 +67,318 @@ type ReleaseInformation struct {
 	tag string
 }
 
+type UpgradeInformation struct {
+	// The repo name
+	repo string
+	// The branch the upgrade PR is based on
+	baseBranch string
+	// The upgrade branch, e.g. auto-upgrade/v1.16.0
+	branch string
+	// The commands to upgrade the dependencies, e.g. go get github.com/goravel/framework@v1.16.0
+	commands []string
+	// The dependencies and the branches they are released from, the preview mode replaces the dependencies
+	// with these branches as the tags don't exist yet.
+	dependencies map[string]string
+	// The upgrade PR title
+	title string
+}
+
 type Release struct {
-	ctx    console.Context
-	github services.Github
-	real   bool
+	apiDiff services.APIDiff
+	// The mutating actions performed in real mode, nil in preview mode
+	auditLog *services.AuditLog
+	// The path of the audit log, storage/release/audit.jsonl if it's empty
+	auditLogPath string
+	// Whether to merge the PRs once their checks pass instead of asking to check the merge status, see mergePRs
+	autoMerge bool
+	// The URL template to clone the repos, see cloneURL
+	cloneURLTemplate string
+	// The max number of repos cloned, upgraded or tested at the same time
+	concurrency int
+	ctx         console.Context
+	git         services.Git
+	github      services.Github
+	goProxy     services.GoProxy
+	manifest    *Manifest
+	// Whether to answer the questions with their policy answers instead of asking, see confirm
+	nonInteractive bool
+	// The merge method of the PRs merged automatically: merge, squash or rebase
+	mergeMethod string
+	// The max time to wait for the PRs to be merged automatically
+	mergeTimeout time.Duration
+	// Who performs the release in the audit log, the current OS user if it's empty
+	operator string
+	// The mutating actions recorded in preview mode
+	plan *services.Plan
+	// The interval to poll GitHub while waiting, defaultPollInterval if it's zero
+	pollInterval time.Duration
+	// The base URL of the Go module proxy to verify the released modules, proxy.golang.org if it's empty
+	proxyURL string
+	real     bool
+	// The release progress, only recorded in real mode
+	state *ReleaseState
+	// The base URL of the checksum database to verify the released modules, sum.golang.org if it's empty
+	sumDBURL string
 }
 
-func NewRelease(ctx console.Context) *Release {
+func NewRelease(ctx console.Context) (*Release, error) {
+	manifest, err := LoadManifest(facades.Config().GetString("release.manifest", "release.yaml"))
+	if err != nil {
+		return nil, withExitCode(ExitCodeInvalidInput, err)
+	}
+
 	release := &Release{
-		ctx: ctx,
+		auditLogPath:     facades.Config().GetString("release.audit_log"),
+		cloneURLTemplate: facades.Config().GetString("release.clone_url", defaultCloneURL),
+		ctx:              ctx,
+		manifest:         manifest,
+		nonInteractive:   ctx.OptionBool("non-interactive"),
+		operator:         facades.Config().GetString("release.operator"),
+		proxyURL:         facades.Config().GetString("release.proxy_url", services.DefaultGoProxyURL),
+		sumDBURL:         facades.Config().GetString("release.sumdb_url", services.DefaultSumDBURL),
 	}
 
-	return release
+	return release, nil
 }
 
 func (r *Release) Major() error {
-	if r.ctx.OptionBool("refresh") {
-		if err := r.refreshGoProxy(); err != nil {
-			r.ctx.Error(err.Error())
-			return nil
-		}
+	version, err := r.resolveVersion(false)
+	if err != nil {
+		return err
+	}
+	if version.Prerelease != "" {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s is a prerelease, release it via the rc command", version))
 	}
 
-	r.real = r.ctx.OptionBool("real")
-	r.github = services.NewGithubImpl(r.real)
-	tag := r.ctx.ArgumentString("tag")
+	return r.releaseMajor("major", version.String())
+}
 
-	var branch string
-	if strings.HasSuffix(tag, ".0") {
-		branch = strings.TrimSuffix(tag, ".0") + ".x"
+// RC releases a prerelease, e.g. v1.17.0-rc.1, the same way as a major release, but the GitHub releases
+// are marked as prereleases and no version branch is pushed or set as the default branch.
+func (r *Release) RC() error {
+	version, err := r.version()
+	if err != nil {
+		return err
+	}
+	if version.Prerelease == "" {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s is not a prerelease, e.g. v1.17.0-rc.1", version))
 	}
 
-	if err := r.testInSubPackages("master"); err != nil {
+	return r.releaseMajor("rc", version.String())
+}
+
+func (r *Release) Patch() error {
+	version, err := r.resolveVersion(true)
+	if err != nil {
 		return err
 	}
+	if version.Prerelease != "" {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s is a prerelease, release it via the rc command", version))
+	}
 
-	packagesReleaseInfo, err := r.getPackagesReleaseInformation(tag)
+	tag := version.String()
+	r.setup()
+	branch, err := r.getBranchFromTag(r.manifest.Framework().Name, tag)
 	if err != nil {
 		return err
 	}
 
-	if !r.ctx.Confirm("Did you confirm the release information?") {
-		if err := r.confirmReleaseInformation(packagesReleaseInfo); err != nil {
-			return err
-		}
+	if err := r.initState("patch", tag); err != nil {
+		return err
+	}
+
+	if err := r.step("test", func() error {
+		return r.testInSubPackages(branch)
+	}); err != nil {
+		return err
+	}
+
+	plan, err := r.getPatchPlan(tag)
+	if err != nil {
+		return err
 	}
 
-	if err := r.releaseFramework(branch, packagesReleaseInfo["framework"]); err != nil {
+	if err := r.checkAPICompatibility(plan); err != nil {
 		return err
 	}
 
-	if err := r.releasePackages(packagesReleaseInfo, tag, branch); err != nil {
+	if !r.confirm("Did you confirm the release information?", false) {
+		if err := r.confirmReleaseInformation(plan.ReleaseInformation()); err != nil {
+			return err
+		}
+	}
+
+	if err := r.applyPlan(plan); err != nil {
 		return err
 	}
 
-	if err := r.releaseExample(tag, branch); err != nil {
+	if err := r.savePlan("patch", tag); err != nil {
 		return err
 	}
 
-	if err := r.releaseGoravel(tag, branch); err != nil {
+	if err := r.verifyRelease(plan); err != nil {
 		return err
 	}
 
-	r.releaseMajorSuccess(tag)
+	r.releasePlanSuccess(plan)
 
 	return nil
 }
 
-func (r *Release) Patch() error {
-	r.real = r.ctx.OptionBool("real")
-	r.github = services.NewGithubImpl(r.real)
-	tag := r.ctx.ArgumentString("tag")
-	branch := r.getBranchFromTag("framework", tag)
+// releaseMajor releases all repos in the manifest level by level, it's shared by the major and rc commands.
+func (r *Release) releaseMajor(command, tag string) error {
+	if r.ctx.OptionBool("refresh") {
+		if err := r.refreshGoProxy(); err != nil {
+			return err
+		}
+	}
+
+	r.setup()
 
-	if err := r.testInSubPackages(branch); err != nil {
+	if err := r.initState(command, tag); err != nil {
 		return err
 	}
 
-	liteReleaseInfo, err := r.getPackageReleaseInformation("goravel-lite", tag)
-	if err != nil {
+	if err := r.step("test", func() error {
+		return r.testInSubPackages("master")
+	}); err != nil {
 		return err
 	}
 
-	frameworkReleaseInfo, err := r.getPackageReleaseInformation("framework", tag)
+	plan, err := r.getMajorPlan(tag)
 	if err != nil {
 		return err
 	}
 
-	if !r.ctx.Confirm("Did you confirm the release information?") {
-		releaseInfos := map[string]*ReleaseInformation{
-			"goravel-lite": liteReleaseInfo,
-			"framework":    frameworkReleaseInfo,
-		}
-		if err := r.confirmReleaseInformation(releaseInfos); err != nil {
+	if err := r.checkAPICompatibility(plan); err != nil {
+		return err
+	}
+
+	if !r.confirm("Did you confirm the release information?", false) {
+		if err := r.confirmReleaseInformation(plan.ReleaseInformation()); err != nil {
 			return err
 		}
 	}
 
-	if err := r.releaseRepo(frameworkReleaseInfo); err != nil {
+	if err := r.applyPlan(plan); err != nil {
 		return err
 	}
 
-	examplePR, err := r.createUpgradePRForExample(tag, []string{
-		fmt.Sprintf("go get github.com/goravel/framework@%s", tag),
-	})
+	if err := r.savePlan(command, tag); err != nil {
+		return err
+	}
+
+	if err := r.verifyRelease(plan); err != nil {
+		return err
+	}
+
+	r.releasePlanSuccess(plan)
+
+	return nil
+}
+
+// Plan resolves the release information of a major or patch release and saves it for the apply command.
+func (r *Release) Plan() error {
+	version, err := r.resolveVersion(r.ctx.OptionBool("patch"))
 	if err != nil {
 		return err
 	}
 
-	litePR, err := r.createUpgradePRForLite(tag, []string{
-		fmt.Sprintf("go get github.com/goravel/framework@%s", tag),
-	})
+	tag := version.String()
+	r.apiDiff = services.NewAPIDiffImpl()
+	r.git = services.NewGitImpl()
+	r.github = services.NewGithubImpl(true, nil, nil)
+
+	var plan *ReleasePlan
+
+	if r.ctx.OptionBool("patch") {
+		if version.Prerelease != "" {
+			return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s is a prerelease, it can't be planned as a patch release", version))
+		}
+
+		plan, err = r.getPatchPlan(tag)
+	} else {
+		plan, err = r.getMajorPlan(tag)
+	}
 	if err != nil {
 		return err
 	}
 
-	if err := r.checkPRsMergeStatus(map[string]*github.PullRequest{
-		"example":      examplePR,
-		"goravel-lite": litePR,
-	}); err != nil {
-		return fmt.Errorf("failed to check upgrade PRs merge status: %w", err)
+	if err := r.checkAPICompatibility(plan); err != nil {
+		return err
+	}
+
+	for _, releaseInfo := range plan.ReleaseInformation() {
+		r.printReleaseInformation(releaseInfo)
+	}
+
+	path := r.ctx.Option("out")
+	if path == "" {
+		path = facades.App().StoragePath("release", fmt.Sprintf("%s-%s-release-plan.json", plan.Command, tag))
+	}
+
+	if err := plan.Save(path); err != nil {
+		return err
+	}
+
+	color.Green().Println(fmt.Sprintf("The release plan has been saved to %s, run `./artisan apply %s --real` to carry it out", path, path))
+
+	return nil
+}
+
+// Apply carries out a release plan saved by the plan command, nothing is resolved again.
+func (r *Release) Apply() error {
+	plan, err := LoadReleasePlan(r.ctx.ArgumentString("file"))
+	if err != nil {
+		return withExitCode(ExitCodeInvalidInput, err)
+	}
+
+	for _, level := range plan.Levels {
+		for _, repo := range level {
+			if manifestRepo := r.manifest.Repo(repo.Repo); manifestRepo == nil || manifestRepo.Owner != repo.Owner {
+				return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s/%s in the release plan is not declared in the manifest", repo.Owner, repo.Repo))
+			}
+		}
+	}
+
+	r.setup()
+
+	if err := r.initState(plan.Command, plan.Tag); err != nil {
+		return err
+	}
+
+	if !r.confirm(fmt.Sprintf("Apply the %s release plan of %s?", plan.Command, plan.Tag), true) {
+		return withExitCode(ExitCodeRefused, fmt.Errorf("the release plan of %s is not applied", plan.Tag))
+	}
+
+	if err := r.applyPlan(plan); err != nil {
+		return err
 	}
 
-	if err := r.releaseRepo(liteReleaseInfo); err != nil {
+	if err := r.savePlan("apply-"+plan.Command, plan.Tag); err != nil {
 		return err
 	}
 
-	if err := r.releaseGoravel(tag, ""); err != nil {
+	if err := r.verifyRelease(plan); err != nil {
 		return err
 	}
 
-	r.releasePatchSuccess(tag)
+	r.releasePlanSuccess(plan)
 
 	return nil
 }
 
 func (r *Release) Preview() error {
-	r.github = services.NewGithubImpl(true)
-	tag := r.ctx.ArgumentString("tag")
+	version, err := r.version()
+	if err != nil {
+		return err
+	}
+
+	tag := version.String()
+	r.github = services.NewGithubImpl(true, nil, nil)
 	containPackages := r.ctx.OptionBool("packages")
 
-	var (
-		releaseInfos map[string]*ReleaseInformation
-		err          error
-	)
+	var releaseInfos map[string]*ReleaseInformation
 
 	if containPackages {
 		releaseInfos, err = r.getPackagesReleaseInformation(tag)
@@ -196,20 +386,10 @@ func (r *Release) Preview() error {
 			return err
 		}
 	} else {
-		liteReleaseInfo, err := r.getPackageReleaseInformation("goravel-lite", tag)
-		if err != nil {
-			return err
-		}
-
-		frameworkReleaseInfo, err := r.getPackageReleaseInformation("framework", tag)
+		releaseInfos, err = r.getPatchReleaseInformation(tag)
 		if err != nil {
 			return err
 		}
-
-		releaseInfos = map[string]*ReleaseInformation{
-			"goravel-lite": liteReleaseInfo,
-			"framework":    frameworkReleaseInfo,
-		}
 	}
 
 	for _, releaseInfo := range releaseInfos {
@@ -219,418 +399,2051 @@ func (r *Release) Preview() error {
 	return nil
 }
 
-func (r *Release) checkGoravelAutoUpgradePRMergeStatus(ctx console.Context) bool {
-	return ctx.Confirm("Is Goravel auto upgrade PR merged? https://github.com/goravel/goravel/pulls")
-}
+// Changelog aggregates the release notes of all repos in the manifest into one Markdown document for the
+// goravel.dev release page, the repos skipping the release are excluded.
+func (r *Release) Changelog() error {
+	version, err := r.version()
+	if err != nil {
+		return err
+	}
 
-func (r *Release) checkPRsMergeStatus(repoToPR map[string]*github.PullRequest) error {
-	for pkg, pr := range repoToPR {
-		if pr == nil {
-			color.Black().Println(fmt.Sprintf("%-10s: no need to upgrade", pkg))
+	tag := version.String()
+	r.github = services.NewGithubImpl(true, nil, nil)
+	changelog := NewReleaseChangelog(tag)
+
+	for _, repo := range r.manifest.Repos {
+		if repo.SkipRelease {
 			continue
 		}
 
-		color.Black().Println(fmt.Sprintf("%-10s: %s", pkg, *pr.HTMLURL+"/files"))
-	}
-
-	for {
-		choice, err := r.ctx.Choice("Check PRs merge status?", []console.Choice{
-			{
-				Key:   "Check",
-				Value: "Check",
-			},
-		})
+		releaseInfo, err := r.getPackageReleaseInformation(repo.Name, tag)
 		if err != nil {
 			return err
 		}
 
-		if choice == "Check" {
-			var notMerged []string
-			for repo, pr := range repoToPR {
-				if pr == nil {
-					continue
-				}
+		changelog.Add(fmt.Sprintf("%s/%s", repo.Owner, repo.Name), releaseInfo.notes.Body)
+	}
 
-				if err := r.ctx.Spinner(fmt.Sprintf("Checking %s/%s merge status...", owner, repo), console.SpinnerOption{
-					Action: func() error {
-						merged, err := r.checkPRMergeStatus(repo, pr)
-						if err != nil {
-							return err
-						}
+	path := r.ctx.Option("out")
+	if path == "" {
+		path = facades.App().StoragePath("release", fmt.Sprintf("changelog-%s.md", tag))
+	}
 
-						if merged {
-							color.Green().Println(fmt.Sprintf("%s/%s merged", owner, repo))
+	if err := changelog.Save(path); err != nil {
+		return err
+	}
 
-							repoToPR[repo] = nil
-						} else {
-							notMerged = append(notMerged, fmt.Sprintf("%s/%s", owner, repo))
-						}
+	color.Green().Println(fmt.Sprintf("The changelog of %d changes has been saved to %s", len(changelog.Entries), path))
 
-						return nil
-					},
-				}); err != nil {
-					return err
-				}
-			}
+	return nil
+}
 
-			if len(notMerged) == 0 {
-				return nil
-			} else {
-				color.Yellow().Println(fmt.Sprintf("Not merged PRs: %s", strings.Join(notMerged, ", ")))
-			}
+// Next prints the next version suggested from the PRs merged since the latest releases, a patch version is
+// suggested from the version branches if no feature is merged into master.
+func (r *Release) Next() error {
+	r.github = services.NewGithubImpl(true, nil, nil)
+	patch := r.ctx.OptionBool("patch")
+
+	version, bump, err := r.getNextVersion(patch)
+	if err != nil {
+		return err
+	}
+	if !patch && bump == BumpPatch {
+		patch = true
+		if version, _, err = r.getNextVersion(patch); err != nil {
+			return err
 		}
 	}
-}
 
-func (r *Release) checkPRMergeStatus(repo string, pr *github.PullRequest) (bool, error) {
-	if pr == nil {
-		return true, nil
+	command := "major"
+	if patch {
+		command = "patch"
 	}
 
-	pr, err := r.github.GetPullRequest(owner, repo, *pr.Number)
+	color.Green().Println(fmt.Sprintf("The next version is %s, release it via `./artisan %s %s`", version, command, version))
+
+	return nil
+}
+
+// Rollback undoes the changes recorded in the states of the releases of the tag in reverse order, see CreatedResource:
+// the open PRs are closed, the default branches are reset, then the pushed branches, the releases and their tags are
+// deleted. Every step is confirmed, the declined ones are kept in the state so the rollback can be run again.
+func (r *Release) Rollback() error {
+	version, err := r.version()
 	if err != nil {
-		return false, err
-	}
-	if pr.Merged != nil && *pr.Merged {
-		return true, nil
+		return err
 	}
 
-	return false, nil
-}
+	tag := version.String()
+	r.real = r.ctx.OptionBool("real")
+	if r.real {
+		r.auditLog = r.newAuditLog()
+		r.auditLog.SetRelease("rollback", tag)
+	} else {
+		r.plan = services.NewPlan()
+	}
+	r.github = services.NewGithubImpl(r.real, r.plan, r.auditLog)
 
-func (r *Release) confirmReleaseInformation(pkgToReleaseInfo map[string]*ReleaseInformation) error {
-	for _, releaseInfo := range pkgToReleaseInfo {
-		r.printReleaseInformation(releaseInfo)
+	var states []*ReleaseState
+	for _, command := range []string{"major", "rc", "patch"} {
+		path := facades.App().StoragePath("release", fmt.Sprintf("%s-%s.json", command, tag))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
 
-		if !r.ctx.Confirm(fmt.Sprintf("%s/%s confirmed?", owner, releaseInfo.repo)) {
-			return fmt.Errorf("%s/%s not confirmed", owner, releaseInfo.repo)
+		state, err := LoadReleaseState(path)
+		if err != nil {
+			return err
 		}
-	}
 
-	return nil
-}
+		states = append(states, state)
+	}
+	if len(states) == 0 {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("no release state of %s found, nothing to roll back", tag))
+	}
 
-func (r *Release) createRelease(repo, tag string, notes *github.RepositoryReleaseNotes) error {
-	_, err := r.github.CreateRelease(owner, repo, &github.RepositoryRelease{
-		TagName:         convert.Pointer(tag),
-		TargetCommitish: convert.Pointer(r.getBranchFromTag(repo, tag)),
-		Name:            convert.Pointer(notes.Name),
-		Body:            convert.Pointer(notes.Body),
-	})
+	var kept int
+	for _, state := range states {
+		remaining, err := r.rollbackState(state)
+		if err != nil {
+			return err
+		}
 
-	return err
-}
+		kept += remaining
+	}
 
-func (r *Release) createUpgradePRForExample(frameworkTag string, dependencies []string) (*github.PullRequest, error) {
-	repo := "example"
+	if !r.real {
+		r.printPlan()
+		color.Yellow().Println(fmt.Sprintf("Preview mode, run `./artisan rollback %s --real` to roll back", tag))
 
-	return r.createUpgradePR(repo, r.getBranchFromTag(repo, frameworkTag), frameworkTag, dependencies)
-}
+		return nil
+	}
+	if kept > 0 {
+		return withExitCode(ExitCodeRefused, fmt.Errorf("%d changes of %s are kept, run the rollback again to undo them", kept, tag))
+	}
 
-func (r *Release) createUpgradePRForLite(frameworkTag string, dependencies []string) (*github.PullRequest, error) {
-	repo := "goravel-lite"
+	color.Green().Println(fmt.Sprintf("Rollback %s success!", tag))
 
-	return r.createUpgradePR(repo, r.getBranchFromTag(repo, frameworkTag), frameworkTag, dependencies)
+	return nil
 }
 
-func (r *Release) createUpgradePRsForPackages(frameworkTag string) (map[string]*github.PullRequest, error) {
-	packageToPR := make(map[string]*github.PullRequest)
-
-	for _, pkg := range packages {
-		pr, err := r.createUpgradePR(pkg, "master", frameworkTag, []string{
-			fmt.Sprintf("go get github.com/goravel/framework@%s", frameworkTag),
-		})
-		if err != nil {
-			return nil, err
-		}
+// Smoke installs a new project via goravel/installer built at the tag, then builds, vets, tests and runs the artisan
+// of the project, see newSmokeSteps. The output of the failed step is printed, followed by a summary of the steps.
+func (r *Release) Smoke() error {
+	version, err := r.version()
+	if err != nil {
+		return err
+	}
 
-		packageToPR[pkg] = pr
+	tag := version.String()
+	installer := r.manifest.Repo("installer")
+	if installer == nil {
+		return withExitCode(ExitCodeInvalidInput, errors.New("installer is not declared in the manifest"))
 	}
 
-	return packageToPR, nil
-}
+	r.git = services.NewGitImpl()
 
-func (r *Release) createUpgradePR(repo, baseBranch, frameworkTag string, dependencies []string) (*github.PullRequest, error) {
+	workspaceDir, err := makeRepoDir(smokeProject)
+	if err != nil {
+		return err
+	}
 	defer func() {
-		_ = facades.Process().Run(fmt.Sprintf("rm -rf %s", repo))
+		_ = os.RemoveAll(workspaceDir)
 	}()
 
-	var pr *github.PullRequest
-
-	dependencyCommands := strings.Join(dependencies, " && ")
+	installerDir := filepath.Join(workspaceDir, installer.Name)
+	if err := os.Mkdir(installerDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", installer.Name, err)
+	}
+	if err := r.clone(installer.Name, installerDir, tag); err != nil {
+		return err
+	}
 
-	if err := r.ctx.Spinner(fmt.Sprintf("Creating upgrade PR for %s...", repo), console.SpinnerOption{
-		Action: func() error {
-			// Clone repo and mod
-			upgradeBranch := "auto-upgrade/" + frameworkTag
-			prTitle := fmt.Sprintf("chore: Upgrade framework to %s (auto)", frameworkTag)
+	var (
+		env      = smokeEnv(r.ctx.Option("goproxy"), r.ctx.Option("goflags"))
+		steps    = newSmokeSteps(installerDir, workspaceDir)
+		statuses = make([]string, len(steps))
+		smokeErr error
+	)
+	for i := range steps {
+		statuses[i] = color.Yellow().Sprint("SKIP")
+	}
 
-			if !r.real {
-				color.Yellow().Println(fmt.Sprintf("Preview mode, skip creating upgrade PR for %s", repo))
-				pr = &github.PullRequest{
-					Title:   convert.Pointer(prTitle),
-					HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/pull/%s", owner, repo, upgradeBranch)),
-					Number:  convert.Pointer(1),
-				}
+	for i, step := range steps {
+		var (
+			output strings.Builder
+			result process.Result
+		)
+		if err := r.ctx.Spinner(fmt.Sprintf("%s...", step.Name), console.SpinnerOption{
+			Action: func() error {
+				result = facades.Process().Path(step.Dir).Env(env).Quietly().OnOutput(func(_ process.OutputType, line []byte) {
+					_, _ = fmt.Fprintln(&output, string(line))
+				}).Run(step.Command)
 
 				return nil
-			}
+			},
+		}); err != nil {
+			return err
+		}
 
-			commandToCloneAndMod := fmt.Sprintf(`rm -rf %s && git clone git@github.com:%s/%s.git &&
-cd %s && git checkout %s && git branch -D %s 2>/dev/null || true && git checkout -b %s &&
-%s && go mod tidy`, repo, owner, repo, repo, baseBranch, upgradeBranch, upgradeBranch, dependencyCommands)
+		if result.Failed() {
+			statuses[i] = color.Red().Sprint("FAIL")
+			color.Red().Println(fmt.Sprintf("[%s] %s failed:", step.Name, step.Command))
+			color.Black().Println(output.String())
+			smokeErr = withExitCode(ExitCodeChecksFailed, fmt.Errorf("smoke test of %s failed at %s: %w", tag, step.Name, result.Error()))
 
-			if res := facades.Process().Run(commandToCloneAndMod); res.Failed() {
-				return fmt.Errorf("failed to clone repo and mod for %s: %w", repo, res.Error())
-			}
+			break
+		}
 
-			// Check status
-			commandToCheckStatus := fmt.Sprintf(`cd %s && git status`, repo)
-			res := facades.Process().Run(commandToCheckStatus)
-			if res.Failed() {
-				return fmt.Errorf("failed to check status for %s: %w", repo, res.Error())
-			}
-			if strings.Contains(res.Output(), "nothing to commit, working tree clean") {
-				color.Yellow().Println(fmt.Sprintf("%s/%s is already up to date", owner, repo))
-				return nil
-			}
+		statuses[i] = color.Green().Sprint("PASS")
+	}
 
-			// Push upgrade branch
-			commandToPush := fmt.Sprintf(`cd %s && git add . && git commit -m "%s" && git push origin %s -f`, repo, prTitle, upgradeBranch)
-			res = facades.Process().Run(commandToPush)
-			if res.Failed() {
-				return fmt.Errorf("failed to push upgrade branch for %s: %w", repo, res.Error())
+	r.divider()
+	for i, step := range steps {
+		r.ctx.TwoColumnDetail(step.Name, statuses[i])
+	}
+	r.divider()
+
+	if smokeErr != nil {
+		return smokeErr
+	}
+
+	color.Green().Println(fmt.Sprintf("The project installed via %s/%s %s works fine", installer.Owner, installer.Name, tag))
+
+	return nil
+}
+
+// Verify checks the Go module proxy and the checksum database serve the tag of the framework and the packages,
+// see verifyModules. A patch release only releases the framework.
+func (r *Release) Verify() error {
+	version, err := r.version()
+	if err != nil {
+		return err
+	}
+
+	tag := version.String()
+	r.goProxy = services.NewGoProxyImpl(r.proxyURL, r.sumDBURL)
+
+	repoToTag := map[string]string{r.manifest.Framework().Name: tag}
+	if !r.ctx.OptionBool("patch") {
+		for _, repo := range r.manifest.Releasable() {
+			repoToTag[repo.Name] = tag
+		}
+	}
+
+	return r.verifyModules(repoToTag, r.ctx.OptionInt("retries"))
+}
+
+// applyPlan releases the repos level by level as the plan resolved.
+func (r *Release) applyPlan(plan *ReleasePlan) error {
+	r.printReleaseOrder(plan.Levels)
+
+	releasedTags := plan.ReleasedTags()
+	for _, level := range plan.Levels {
+		if err := r.releaseLevel(level, plan.Tag, plan.Branch, releasedTags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// audit appends the git push to the audit log, the GitHub API actions are appended by services.GithubImpl.
+func (r *Release) audit(actionType services.PlanActionType, repo string, payload any, actionErr error) {
+	if r.auditLog == nil {
+		return
+	}
+
+	if err := r.auditLog.Append(actionType, r.owner(repo), repo, payload, 0, r.cloneURL(repo), actionErr); err != nil {
+		color.Red().Println(err.Error())
+	}
+}
+
+// checkAPICompatibility diffs the exported API of the framework and the packages between the previous tag and
+// the release branch, the incompatible changes are recorded in the plan and listed in the release information.
+// A patch release is blocked by any incompatible change unless --allow-breaking is set.
+func (r *Release) checkAPICompatibility(plan *ReleasePlan) error {
+	var (
+		repos         []string
+		repoToRelease = make(map[string]*ReleasePlanRelease)
+	)
+	for _, level := range plan.Levels {
+		for _, repo := range level {
+			if manifestRepo := r.manifest.Repo(repo.Repo); manifestRepo == nil || manifestRepo.Role == RoleApp {
+				continue
+			}
+			if repo.Release == nil || repo.Release.PreviousTag == "" {
+				continue
+			}
+
+			repos = append(repos, repo.Repo)
+			repoToRelease[repo.Repo] = repo.Release
+		}
+	}
+
+	if err := r.runRepoTasks(fmt.Sprintf("Checking API compatibility of %s...", strings.Join(repos, ", ")), repos, func(repo, dir string, output io.Writer) error {
+		release := repoToRelease[repo]
+		changes, err := r.getIncompatibleChanges(repo, dir, release.PreviousTag, release.Branch)
+		if err != nil {
+			return err
+		}
+
+		release.IncompatibleChanges = changes
+		for _, change := range changes {
+			_, _ = fmt.Fprintln(output, change)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var breakingRepos []string
+	for _, repo := range repos {
+		if len(repoToRelease[repo].IncompatibleChanges) > 0 {
+			breakingRepos = append(breakingRepos, repo)
+		}
+	}
+
+	if plan.Command != "patch" || len(breakingRepos) == 0 {
+		return nil
+	}
+
+	if r.ctx.OptionBool("allow-breaking") {
+		color.Yellow().Println(fmt.Sprintf("The incompatible API changes of %s are allowed by --allow-breaking", strings.Join(breakingRepos, ", ")))
+
+		return nil
+	}
+
+	return withExitCode(ExitCodeIncompatibleAPI, fmt.Errorf("incompatible API changes found in %s, a patch release should not break the API, pass --allow-breaking to release anyway", strings.Join(breakingRepos, ", ")))
+}
+
+// checkCIStatus gates the release on the checks at the head of the branch, see newCommitChecks. Any failing check
+// blocks the release, and so do the pending checks unless they pass within the --wait-checks minutes. The preview
+// only records the state of the checks in the plan, it neither waits nor fails.
+func (r *Release) checkCIStatus(repo, branch string) error {
+	owner := r.owner(repo)
+	if !r.real {
+		checks, err := r.getCommitChecks(repo, branch, branch)
+		if err != nil {
+			return err
+		}
+
+		failing, pending := filterChecks(checks)
+		r.plan.Add(services.PlanActionCheckStatus, owner, repo, &services.CheckStatusPayload{
+			Branch:  branch,
+			Failing: failing,
+			Pending: pending,
+		})
+		color.Black().Println(fmt.Sprintf("[%s/%s] %d checks at %s: %d failing, %d pending, the checks gate the release in real mode only", owner, repo, len(checks), branch, len(failing), len(pending)))
+
+		return nil
+	}
+
+	wait := time.Duration(r.ctx.OptionInt("wait-checks")) * time.Minute
+	deadline := time.Now().Add(wait)
+
+	interval := r.pollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		checks, err := r.getCommitChecks(repo, branch, branch)
+		if err != nil {
+			return err
+		}
+
+		for _, check := range checks {
+			switch check.State {
+			case CheckFailure:
+				color.Red().Println(fmt.Sprintf("[%s/%s] Check %s is failing: %s", owner, repo, check.Name, check.URL))
+			case CheckPending:
+				color.Yellow().Println(fmt.Sprintf("[%s/%s] Check %s is pending: %s", owner, repo, check.Name, check.URL))
+			}
+		}
+
+		failing, pending := filterChecks(checks)
+		if len(failing) > 0 {
+			return withExitCode(ExitCodeChecksFailed, fmt.Errorf("the checks of %s/%s at %s are failing: %s", owner, repo, branch, strings.Join(failing, ", ")))
+		}
+		if len(pending) == 0 {
+			color.Green().Println(fmt.Sprintf("[%s/%s] All %d checks at %s passed", owner, repo, len(checks), branch))
+
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			if wait == 0 {
+				return withExitCode(ExitCodePending, fmt.Errorf("the checks of %s/%s at %s are pending: %s, pass --wait-checks to wait for them", owner, repo, branch, strings.Join(pending, ", ")))
+			}
+
+			return withExitCode(ExitCodePending, fmt.Errorf("the checks of %s/%s at %s are still pending after %s: %s", owner, repo, branch, wait, strings.Join(pending, ", ")))
+		}
+
+		color.Yellow().Println(fmt.Sprintf("[%s/%s] Waiting for the pending checks at %s...", owner, repo, branch))
+		time.Sleep(interval)
+	}
+}
+
+// checkAutoUpgradePR waits for the auto upgrade PR of the repo, e.g. goravel/goravel, created by its own workflow
+// once the framework is released. The PR is found against the release branch, its diff summary is printed, and the
+// release goes on once the PR is merged and the go.mod at the release branch requires the released tags.
+func (r *Release) checkAutoUpgradePR(repo *ReleasePlanRepo, tag string, releasedTags map[string]string) error {
+	owner := repo.Owner
+
+	// The auto upgrade PR is only created once the framework is released, it can't be found in preview mode.
+	if !r.real {
+		color.Black().Println(fmt.Sprintf("[%s/%s] The auto upgrade PR to %s is checked in real mode only", owner, repo.Repo, tag))
+
+		return nil
+	}
+
+	manifestRepo := r.manifest.Repo(repo.Repo)
+	if manifestRepo == nil {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("%s/%s is not in the manifest", owner, repo.Repo))
+	}
+
+	releasedModules := make(map[string]string)
+	for name, releasedTag := range releasedTags {
+		if releasedRepo := r.manifest.Repo(name); releasedRepo != nil {
+			releasedModules[releasedRepo.Module()] = releasedTag
+		}
+	}
+
+	interval := r.pollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	branch, err := r.getBranchFromTag(repo.Repo, tag)
+	if err != nil {
+		return err
+	}
+
+	var (
+		deadline = time.Now().Add(r.mergeTimeout)
+		pr       *github.PullRequest
+		reason   string
+	)
+	for {
+		prs, err := r.github.GetPullRequests(owner, repo.Repo, &github.PullRequestListOptions{
+			State:       "all",
+			Base:        branch,
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return err
+		}
+
+		found := findAutoUpgradePR(prs, tag)
+		if found != nil && pr == nil {
+			files, err := r.github.GetPullRequestFiles(owner, repo.Repo, found.GetNumber())
+			if err != nil {
+				return err
+			}
+
+			color.Green().Println(fmt.Sprintf("[%s/%s] Found the auto upgrade PR: %s", owner, repo.Repo, found.GetHTMLURL()))
+			for _, summary := range diffSummary(files) {
+				color.Black().Println(fmt.Sprintf("  %s", summary))
+			}
+		}
+		pr = found
+
+		switch {
+		case pr == nil:
+			reason = fmt.Sprintf("the auto upgrade PR to %s is not found against %s", tag, branch)
+		case !pr.GetMerged():
+			reason = fmt.Sprintf("%s is not merged", pr.GetHTMLURL())
+		default:
+			goMod, err := r.getGoMod(manifestRepo, branch)
+			if err != nil {
+				return err
+			}
+
+			mismatches, err := goModMismatches(repo.Repo, []byte(goMod), r.manifest.Framework().Module(), releasedModules)
+			if err != nil {
+				return err
+			}
+			if len(mismatches) == 0 {
+				color.Green().Println(fmt.Sprintf("[%s/%s] %s merged, go.mod at %s requires the released tags", owner, repo.Repo, pr.GetHTMLURL(), branch))
+
+				return nil
+			}
+
+			// The raw go.mod is cached for a few minutes after the PR is merged, check it again.
+			reason = fmt.Sprintf("go.mod at %s doesn't require the released tags: %s", branch, strings.Join(mismatches, "; "))
+		}
+
+		if !time.Now().Before(deadline) {
+			return withExitCode(ExitCodePending, fmt.Errorf("%s/%s: %s after %s", owner, repo.Repo, reason, r.mergeTimeout))
+		}
+
+		color.Yellow().Println(fmt.Sprintf("[%s/%s] Waiting, %s, check again in %s...", owner, repo.Repo, reason, interval))
+		time.Sleep(interval)
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+func (r *Release) checkPRsMergeStatus(repoToPR map[string]*github.PullRequest) error {
+	if r.autoMerge || r.nonInteractive {
+		return r.mergePRs(repoToPR)
+	}
+
+	for pkg, pr := range repoToPR {
+		if pr == nil {
+			color.Black().Println(fmt.Sprintf("%-10s: no need to upgrade", pkg))
+			continue
+		}
+
+		color.Black().Println(fmt.Sprintf("%-10s: %s", pkg, *pr.HTMLURL+"/files"))
+	}
+
+	for {
+		choice, err := r.ctx.Choice("Check PRs merge status?", []console.Choice{
+			{
+				Key:   "Check",
+				Value: "Check",
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if choice == "Check" {
+			var notMerged []string
+			for repo, pr := range repoToPR {
+				if pr == nil {
+					continue
+				}
+
+				owner := r.owner(repo)
+				if err := r.ctx.Spinner(fmt.Sprintf("Checking %s/%s merge status...", owner, repo), console.SpinnerOption{
+					Action: func() error {
+						merged, err := r.checkPRMergeStatus(repo, pr)
+						if err != nil {
+							return err
+						}
+
+						if merged {
+							color.Green().Println(fmt.Sprintf("%s/%s merged", owner, repo))
+
+							repoToPR[repo] = nil
+						} else {
+							notMerged = append(notMerged, fmt.Sprintf("%s/%s", owner, repo))
+						}
+
+						return nil
+					},
+				}); err != nil {
+					return err
+				}
+			}
+
+			if len(notMerged) == 0 {
+				return nil
+			} else {
+				color.Yellow().Println(fmt.Sprintf("Not merged PRs: %s", strings.Join(notMerged, ", ")))
+			}
+		}
+	}
+}
+
+func (r *Release) checkPRMergeStatus(repo string, pr *github.PullRequest) (bool, error) {
+	if pr == nil {
+		return true, nil
+	}
+
+	// The upgrade PRs are only planned in preview mode, consider them merged.
+	if !r.real {
+		return true, nil
+	}
+
+	pr, err := r.github.GetPullRequest(r.owner(repo), repo, *pr.Number)
+	if err != nil {
+		return false, err
+	}
+	if pr.Merged != nil && *pr.Merged {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// mergePRs waits for the checks of the PRs and merges them via the merge method once all checks pass, the interval
+// between the polls doubles up to maxPollInterval. Without --auto-merge, e.g. in non-interactive mode, the PRs are
+// left to be merged by a maintainer instead. A PR is given up if its checks fail, it's closed or it fails to merge,
+// and the PRs still pending after the merge timeout time out. The result of every PR is summarized at the end.
+func (r *Release) mergePRs(repoToPR map[string]*github.PullRequest) error {
+	if !slices.Contains(mergeMethods, r.mergeMethod) {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("invalid merge method %s, it should be one of %s", r.mergeMethod, strings.Join(mergeMethods, ", ")))
+	}
+
+	var pending []string
+	for repo, pr := range repoToPR {
+		if pr == nil {
+			color.Black().Println(fmt.Sprintf("%-10s: no need to upgrade", repo))
+			continue
+		}
+
+		color.Black().Println(fmt.Sprintf("%-10s: %s", repo, pr.GetHTMLURL()+"/files"))
+		pending = append(pending, repo)
+	}
+	slices.Sort(pending)
+
+	// The upgrade PRs are only planned in preview mode, consider them merged.
+	if !r.real || len(pending) == 0 {
+		return nil
+	}
+
+	interval := r.pollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	var (
+		deadline = time.Now().Add(r.mergeTimeout)
+		results  = make(map[string]*RepoResult)
+	)
+	for {
+		var waiting []string
+		for _, repo := range pending {
+			owner := r.owner(repo)
+			merged, err := r.mergePR(repo, repoToPR[repo])
+			switch {
+			case err != nil:
+				color.Red().Println(fmt.Sprintf("[%s/%s] %v", owner, repo, err))
+				results[repo] = &RepoResult{Repo: repo, Err: fmt.Errorf("%s/%s: %w", owner, repo, err)}
+			case merged:
+				color.Green().Println(fmt.Sprintf("[%s/%s] %s merged", owner, repo, repoToPR[repo].GetHTMLURL()))
+				results[repo] = &RepoResult{Repo: repo}
+			default:
+				waiting = append(waiting, repo)
+			}
+		}
+
+		pending = waiting
+		if len(pending) == 0 || !time.Now().Before(deadline) {
+			break
+		}
+
+		color.Yellow().Println(fmt.Sprintf("Waiting for the checks of %s, check again in %s...", strings.Join(pending, ", "), interval))
+		time.Sleep(interval)
+		interval = min(interval*2, maxPollInterval)
+	}
+
+	for _, repo := range pending {
+		results[repo] = &RepoResult{
+			Repo: repo,
+			Err:  withExitCode(ExitCodePending, fmt.Errorf("%s/%s: %s is not merged after %s", r.owner(repo), repo, repoToPR[repo].GetHTMLURL(), r.mergeTimeout)),
+		}
+	}
+
+	var sortedResults []*RepoResult
+	for _, repo := range slices.Sorted(maps.Keys(results)) {
+		sortedResults = append(sortedResults, results[repo])
+	}
+	r.printSummary(sortedResults)
+
+	return poolError(sortedResults)
+}
+
+// mergePR merges the PR if all its checks pass, it returns false if the checks are pending or not reported yet, or
+// the PR is left to be merged by a maintainer. A PR without any check is merged after noChecksGracePeriod. A PR
+// merged by someone else counts as merged, and an error is returned if the PR can't be merged anymore.
+func (r *Release) mergePR(repo string, pr *github.PullRequest) (bool, error) {
+	owner := r.owner(repo)
+
+	pr, err := r.github.GetPullRequest(owner, repo, pr.GetNumber())
+	if err != nil {
+		return false, err
+	}
+	if pr.GetMerged() {
+		return true, nil
+	}
+	if pr.GetState() == "closed" {
+		return false, fmt.Errorf("%s is closed without being merged", pr.GetHTMLURL())
+	}
+
+	checks, err := r.getCommitChecks(repo, pr.GetBase().GetRef(), pr.GetHead().GetRef())
+	if err != nil {
+		return false, err
+	}
+
+	failing, pending := filterChecks(checks)
+	if len(failing) > 0 {
+		return false, withExitCode(ExitCodeChecksFailed, fmt.Errorf("the checks of %s are failing: %s", pr.GetHTMLURL(), strings.Join(failing, ", ")))
+	}
+	// The checks are reported a while after the PR is created, don't merge before any is reported unless the repo
+	// has no CI. A required check not reported yet counts as pending, so no check at all means none is required.
+	if len(checks) == 0 && time.Since(pr.GetCreatedAt().Time) < noChecksGracePeriod {
+		return false, nil
+	}
+	if len(pending) > 0 || !r.autoMerge {
+		return false, nil
+	}
+
+	if err := r.github.MergePullRequest(owner, repo, pr.GetNumber(), r.mergeMethod); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// clone clones the repo into the dir and checks out the branch.
+func (r *Release) clone(repo, dir, branch string) error {
+	if err := r.git.Clone(r.cloneURL(repo), dir); err != nil {
+		return fmt.Errorf("failed to clone %s: %w", repo, err)
+	}
+	if err := r.git.Checkout(dir, branch); err != nil {
+		return fmt.Errorf("failed to checkout %s for %s: %w", branch, repo, err)
+	}
+
+	return nil
+}
+
+// cloneURL fills the clone URL template with the owner and the repo, a template with a single %s
+// takes owner/repo, e.g. file:///tmp/fixtures/%s.git.
+func (r *Release) cloneURL(repo string) string {
+	template := r.cloneURLTemplate
+	if template == "" {
+		template = defaultCloneURL
+	}
+
+	if strings.Count(template, "%s") == 1 {
+		return fmt.Sprintf(template, r.owner(repo)+"/"+repo)
+	}
+
+	return fmt.Sprintf(template, r.owner(repo), repo)
+}
+
+// confirm asks the question, it's answered with the policy answer instead in non-interactive mode: yes if the
+// release is gated by the automated checks anyway, no if the answer leads to an automated check, e.g. the tests.
+func (r *Release) confirm(question string, policy bool) bool {
+	if !r.nonInteractive {
+		return r.ctx.Confirm(question)
+	}
+
+	answer := "No"
+	if policy {
+		answer = "Yes"
+	}
+	color.Black().Println(fmt.Sprintf("%s %s, non-interactive mode", question, answer))
+
+	return policy
+}
+
+func (r *Release) confirmReleaseInformation(pkgToReleaseInfo map[string]*ReleaseInformation) error {
+	for _, releaseInfo := range pkgToReleaseInfo {
+		r.printReleaseInformation(releaseInfo)
+
+		owner := r.owner(releaseInfo.repo)
+		if !r.confirm(fmt.Sprintf("%s/%s confirmed?", owner, releaseInfo.repo), true) {
+			return withExitCode(ExitCodeRefused, fmt.Errorf("%s/%s not confirmed", owner, releaseInfo.repo))
+		}
+	}
+
+	return nil
+}
+
+func (r *Release) createRelease(repo, tag, branch string, notes *github.RepositoryReleaseNotes) error {
+	release := &github.RepositoryRelease{
+		TagName:         convert.Pointer(tag),
+		TargetCommitish: convert.Pointer(branch),
+		Name:            convert.Pointer(notes.Name),
+		Body:            convert.Pointer(notes.Body),
+	}
+	if version, err := services.ParseVersion(tag); err == nil && version.Prerelease != "" {
+		release.Prerelease = convert.Pointer(true)
+	}
+
+	created, err := r.github.CreateRelease(r.owner(repo), repo, release)
+	if err != nil {
+		return err
+	}
+
+	// The tag is created along with the release, so both are deleted by the rollback.
+	return r.recordCreated(&CreatedResource{Kind: CreatedRelease, Repo: repo, Name: tag, ID: created.GetID()})
+}
+
+// createUpgradePR creates the upgrade PR for the repo cloned in the dir, the output of the commands is written to output.
+// It returns nil if the repo is already up to date.
+func (r *Release) createUpgradePR(dir string, output io.Writer, upgrade *UpgradeInformation) (*github.PullRequest, error) {
+	var (
+		repo          = upgrade.repo
+		baseBranch    = upgrade.baseBranch
+		upgradeBranch = upgrade.branch
+		prTitle       = upgrade.title
+	)
+
+	// Clone repo and mod
+	if err := r.clone(repo, dir, baseBranch); err != nil {
+		return nil, err
+	}
+	if err := r.git.CreateBranch(dir, upgradeBranch); err != nil {
+		return nil, fmt.Errorf("failed to create upgrade branch for %s: %w", repo, err)
+	}
+
+	commands := upgrade.commands
+	if !r.real {
+		previewCommands, cleanup, err := r.getPreviewUpgradeCommands(upgrade)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+
+		commands = previewCommands
+	}
+	if res := r.runInDir(dir, output, strings.Join(append(slices.Clone(commands), "go mod tidy"), " && ")); res.Failed() {
+		return nil, fmt.Errorf("failed to upgrade dependencies for %s: %w", repo, res.Error())
+	}
+
+	pr, err := r.pushPR(dir, output, repo, baseBranch, upgradeBranch, prTitle, "upgrade", upgrade.commands)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+
+	if r.state != nil {
+		if err := r.state.SetPR(repo, pr.GetNumber()); err != nil {
+			return nil, err
+		}
+	}
+
+	return pr, nil
+}
+
+// createUpgradePRs creates the upgrade PRs concurrently, the PRs created by a previous run are reattached.
+func (r *Release) createUpgradePRs(upgrades []*UpgradeInformation) (map[string]*github.PullRequest, error) {
+	var (
+		mu            sync.Mutex
+		repos         []string
+		repoToPR      = make(map[string]*github.PullRequest)
+		repoToUpgrade = make(map[string]*UpgradeInformation)
+	)
+
+	for _, upgrade := range upgrades {
+		pr, err := r.getRecordedPR(upgrade.repo)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			repoToPR[upgrade.repo] = pr
+			continue
+		}
+
+		repos = append(repos, upgrade.repo)
+		repoToUpgrade[upgrade.repo] = upgrade
+	}
+
+	if err := r.runRepoTasks(fmt.Sprintf("Creating upgrade PRs for %s...", strings.Join(repos, ", ")), repos, func(repo, dir string, output io.Writer) error {
+		pr, err := r.createUpgradePR(dir, output, repoToUpgrade[repo])
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		repoToPR[repo] = pr
+		mu.Unlock()
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return repoToPR, nil
+}
+
+// createVersionPR rewrites the Version constant in the version file of the repo cloned in the dir to the tag
+// to release, then creates the PR against the release branch. It returns nil if the constant is already updated.
+func (r *Release) createVersionPR(dir string, output io.Writer, releaseInfo *ReleaseInformation, versionFile string) (*github.PullRequest, error) {
+	var (
+		repo          = releaseInfo.repo
+		versionBranch = "auto-version/" + releaseInfo.tag
+		prTitle       = fmt.Sprintf("chore: Update version to %s (auto)", releaseInfo.tag)
+	)
+
+	if err := r.clone(repo, dir, releaseInfo.branch); err != nil {
+		return nil, err
+	}
+	if err := r.git.CreateBranch(dir, versionBranch); err != nil {
+		return nil, fmt.Errorf("failed to create version branch for %s: %w", repo, err)
+	}
+
+	path := filepath.Join(dir, versionFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s of %s: %w", versionFile, repo, err)
+	}
+
+	matches := versionRegexp.FindSubmatchIndex(content)
+	if matches == nil {
+		return nil, fmt.Errorf("could not extract %s/%s version from code", r.owner(repo), repo)
+	}
+
+	updated := slices.Concat(content[:matches[2]], []byte(releaseInfo.tag), content[matches[3]:])
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s of %s: %w", versionFile, repo, err)
+	}
+
+	return r.pushPR(dir, output, repo, releaseInfo.branch, versionBranch, prTitle, "version", nil)
+}
+
+func (r *Release) divider() {
+	r.ctx.TwoColumnDetail("", "", '-')
+}
+
+// getDependencyBranches returns the branches the dependencies are released from, master if a dependency isn't released.
+func (r *Release) getDependencyBranches(dependencies []string, releaseInfos map[string]*ReleaseInformation) map[string]string {
+	branches := make(map[string]string, len(dependencies))
+	for _, dependency := range dependencies {
+		branches[dependency] = "master"
+		if releaseInfo := releaseInfos[dependency]; releaseInfo != nil {
+			branches[dependency] = releaseInfo.branch
+		}
+	}
+
+	return branches
+}
+
+// getDependencyGraph builds the dependency graph of the manifest repos by reading their go.mod on the branch.
+func (r *Release) getDependencyGraph(branch string) (*DependencyGraph, error) {
+	graph := NewDependencyGraph(r.manifest)
+
+	if err := r.ctx.Spinner("Building release dependency graph...", console.SpinnerOption{
+		Action: func() error {
+			for _, repo := range r.manifest.Repos {
+				goMod, err := r.getGoMod(repo, branch)
+				if err != nil {
+					return err
+				}
+
+				if err := graph.AddGoMod(repo.Name, []byte(goMod)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+func (r *Release) getBranchFromTag(repo, tag string) (string, error) {
+	owner := r.owner(repo)
+	version, err := parseVersion(tag)
+	if err != nil {
+		return "", err
+	}
+
+	branch := version.Branch()
+
+	exist, err := r.github.CheckBranchExists(owner, repo, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to check branch %s exist for %s/%s: %w", branch, owner, repo, err)
+	}
+
+	if !exist {
+		branch = "master"
+	}
+
+	return branch, nil
+}
+
+// getRecordedPR reattaches to the upgrade PR created by a previous run, it returns nil
+// if no PR is recorded or the recorded PR has been closed without merging.
+func (r *Release) getRecordedPR(repo string) (*github.PullRequest, error) {
+	if r.state == nil {
+		return nil, nil
+	}
+
+	number, exist := r.state.PR(repo)
+	if !exist {
+		return nil, nil
+	}
+
+	pr, err := r.github.GetPullRequest(r.owner(repo), repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if pr.GetState() == "closed" && !pr.GetMerged() {
+		return nil, nil
+	}
+
+	color.Yellow().Println(fmt.Sprintf("Reattach to %s/%s upgrade PR: %s", r.owner(repo), repo, pr.GetHTMLURL()))
+
+	return pr, nil
+}
+
+func (r *Release) getPackagesReleaseInformation(tag string) (map[string]*ReleaseInformation, error) {
+	return r.getReposReleaseInformation(r.manifest.Releasable(), tag)
+}
+
+// getPatchReleaseInformation gets the release information of the framework and the apps,
+// they are the repos released in a patch release.
+func (r *Release) getPatchReleaseInformation(tag string) (map[string]*ReleaseInformation, error) {
+	return r.getReposReleaseInformation(r.patchRepos(), tag)
+}
+
+func (r *Release) getReposReleaseInformation(repos []*Repo, tag string) (map[string]*ReleaseInformation, error) {
+	repoToReleaseInfo := make(map[string]*ReleaseInformation, 0)
+
+	for _, repo := range repos {
+		releaseInfo, err := r.getPackageReleaseInformation(repo.Name, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		repoToReleaseInfo[repo.Name] = releaseInfo
+	}
+
+	return repoToReleaseInfo, nil
+}
+
+func (r *Release) getPackageReleaseInformation(repo string, tag string) (*ReleaseInformation, error) {
+	var releaseInformation *ReleaseInformation
+
+	if err := r.ctx.Spinner(fmt.Sprintf("Getting %s release information for %s...", repo, tag), console.SpinnerOption{
+		Action: func() error {
+			latestTag, latestTagDecisions, err := r.getLatestTag(repo, tag)
+			if err != nil {
+				return err
+			}
+
+			branch, err := r.getBranchFromTag(repo, tag)
+			if err != nil {
+				return err
 			}
-			if !strings.Contains(res.Output(), prTitle) {
-				return fmt.Errorf("failed to push upgrade branch for %s: %s", repo, res.Output())
+
+			notes, err := r.generateReleaseNotes(repo, tag, latestTag, branch)
+			if err != nil {
+				return err
+			}
+
+			releaseInformation = &ReleaseInformation{
+				branch:             branch,
+				notes:              notes,
+				tag:                tag,
+				latestTag:          latestTag,
+				latestTagDecisions: latestTagDecisions,
+				repo:               repo,
+			}
+
+			if manifestRepo := r.manifest.Repo(repo); manifestRepo != nil && manifestRepo.VersionFile != "" {
+				currentTag, err := r.getCurrentTag(repo, fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/refs/heads/%s/%s",
+					manifestRepo.Owner, repo, branch, manifestRepo.VersionFile))
+				if err != nil {
+					return err
+				}
+
+				releaseInformation.currentTag = currentTag
+			}
+
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return releaseInformation, nil
+}
+
+// getCommitChecks gets the statuses and the check runs at the ref, filtered by the checks required by the branch,
+// e.g. the base branch of a PR.
+func (r *Release) getCommitChecks(repo, branch, ref string) ([]*CommitCheck, error) {
+	owner := r.owner(repo)
+
+	required, err := r.github.GetRequiredStatusChecks(owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := r.github.GetCombinedStatus(owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	checkRuns, err := r.github.GetCheckRuns(owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCommitChecks(status, checkRuns, required), nil
+}
+
+// getIncompatibleChanges clones the repo at the previous tag and at the branch into the dir, then returns the
+// incompatible API changes of the branch.
+func (r *Release) getIncompatibleChanges(repo, dir, previousTag, branch string) ([]string, error) {
+	oldDir := filepath.Join(dir, "old")
+	newDir := filepath.Join(dir, "new")
+	for _, path := range []string{oldDir, newDir} {
+		if err := os.Mkdir(path, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", repo, err)
+		}
+	}
+
+	if err := r.clone(repo, oldDir, previousTag); err != nil {
+		return nil, err
+	}
+	if err := r.clone(repo, newDir, branch); err != nil {
+		return nil, err
+	}
+
+	changes, err := r.apiDiff.Incompatible(oldDir, newDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check API compatibility of %s between %s and %s: %w", repo, previousTag, branch, err)
+	}
+
+	return changes, nil
+}
+
+func (r *Release) getGoMod(repo *Repo, branch string) (string, error) {
+	response, err := facades.Http().Get(fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/refs/heads/%s/go.mod", repo.Owner, repo.Name, branch))
+	if err != nil {
+		return "", err
+	}
+	if response.Failed() {
+		return "", fmt.Errorf("failed to get go.mod of %s/%s on %s: %d", repo.Owner, repo.Name, branch, response.Status())
+	}
+
+	return response.Body()
+}
+
+func (r *Release) getCurrentTag(repo, url string) (string, error) {
+	response, err := facades.Http().Get(url)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := response.Body()
+	if err != nil {
+		return "", err
+	}
+
+	// Extract version from body using regex
+	matches := versionRegexp.FindStringSubmatch(body)
+	var currentVersion string
+	if len(matches) > 1 {
+		currentVersion = matches[1]
+	} else {
+		return "", fmt.Errorf("could not extract %s/%s version from code", r.owner(repo), repo)
+	}
+
+	return currentVersion, nil
+}
+
+// generateReleaseNotes builds the release notes from the PRs merged since the previous tag, see buildReleaseNotes.
+func (r *Release) generateReleaseNotes(repo, tag, previousTag, branch string) (*github.RepositoryReleaseNotes, error) {
+	notes, err := r.github.GenerateReleaseNotes(r.owner(repo), repo, &github.GenerateNotesOptions{
+		TagName:         tag,
+		PreviousTagName: convert.Pointer(previousTag),
+		TargetCommitish: convert.Pointer(branch),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if notes == nil {
+		return nil, fmt.Errorf("failed to generate release notes, notes is nil")
+	}
+
+	// The first release has no range to compare, the notes generated by GitHub are kept.
+	if previousTag == "" {
+		return notes, nil
+	}
+
+	commits, err := r.github.CompareCommits(r.owner(repo), repo, previousTag, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	notes.Body = buildReleaseNotes(r.owner(repo), repo, previousTag, tag, commits, notes.Body)
+
+	return notes, nil
+}
+
+// getLatestTag returns the previous tag the release notes are generated against, and how it's resolved
+// if the choice is ambiguous. The tag is empty if the repo has never been released.
+func (r *Release) getLatestTag(repo, tag string) (string, []string, error) {
+	version, err := parseVersion(tag)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A prerelease is released against the previous prerelease if any, e.g. v1.17.0-rc.2 against v1.17.0-rc.1.
+	latestRelease, err := r.github.GetLatestRelease(r.owner(repo), repo, tag, version.Prerelease != "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if latestRelease == nil {
+		return "", nil, nil
+	}
+
+	return latestRelease.Tag, latestRelease.Decisions, nil
+}
+
+// getMajorPlan resolves the release information of a major release, the repos are ordered by the dependency graph
+// and every repo depending on other repos is upgraded via an upgrade PR against master.
+func (r *Release) getMajorPlan(tag string) (*ReleasePlan, error) {
+	version, err := services.ParseVersion(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := r.getDependencyGraph("master")
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := graph.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	releaseInfos, err := r.getPackagesReleaseInformation(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ReleasePlan{
+		Command: "major",
+		Tag:     tag,
+	}
+	if version.Prerelease != "" {
+		plan.Command = "rc"
+	}
+	if version.IsMajor() {
+		plan.Branch = version.Branch()
+	}
+
+	for _, level := range levels {
+		var planLevel []*ReleasePlanRepo
+		for _, repo := range level {
+			var upgrade *UpgradeInformation
+			if dependencies := graph.Dependencies(repo.Name); !repo.AutoUpgrade && len(dependencies) > 0 {
+				upgrade = r.newUpgradeInformation(repo.Name, "master", tag, r.getUpgradeCommands(dependencies, tag), r.getDependencyBranches(dependencies, releaseInfos))
+			}
+
+			planLevel = append(planLevel, newReleasePlanRepo(repo, upgrade, releaseInfos[repo.Name], plan.Branch != ""))
+		}
+
+		plan.Levels = append(plan.Levels, planLevel)
+	}
+
+	return plan, nil
+}
+
+// getNextVersion suggests the next version from the PRs merged since the latest releases and prints the reasoning
+// of every repo. The major version is suggested from the PRs merged into master, the patch version from the PRs
+// merged into the version branches, and a patch is refused if any feature or breaking change landed there.
+func (r *Release) getNextVersion(patch bool) (*services.Version, VersionBump, error) {
+	framework := r.manifest.Framework()
+	latest, err := r.github.GetLatestRelease(framework.Owner, framework.Name, "", false)
+	if err != nil {
+		return nil, BumpPatch, err
+	}
+	if latest == nil {
+		return nil, BumpPatch, fmt.Errorf("%s/%s has never been released, the next version can't be suggested", framework.Owner, framework.Name)
+	}
+
+	latestVersion, err := services.ParseVersion(latest.Tag)
+	if err != nil {
+		return nil, BumpPatch, err
+	}
+
+	repos := r.manifest.Releasable()
+	if patch {
+		repos = r.patchRepos()
+	}
+
+	var versionBumps []*RepoVersionBump
+	if err := r.ctx.Spinner("Checking the PRs merged since the latest releases...", console.SpinnerOption{
+		Action: func() error {
+			for _, repo := range repos {
+				versionBump, err := r.getRepoVersionBump(repo, patch)
+				if err != nil {
+					return err
+				}
+
+				versionBumps = append(versionBumps, versionBump)
+			}
+
+			return nil
+		},
+	}); err != nil {
+		return nil, BumpPatch, err
+	}
+
+	bump := BumpPatch
+	var bumpedRepos []string
+	for _, versionBump := range versionBumps {
+		r.printVersionBump(versionBump)
+
+		if versionBump.Bump > BumpPatch {
+			bumpedRepos = append(bumpedRepos, versionBump.Repo)
+		}
+		bump = max(bump, versionBump.Bump)
+	}
+
+	if patch && bump > BumpPatch {
+		return nil, bump, withExitCode(ExitCodeRefused, fmt.Errorf("a patch release is refused, feature or breaking PRs landed on the version branches of %s", strings.Join(bumpedRepos, ", ")))
+	}
+
+	return bumpVersion(latestVersion, bump), bump, nil
+}
+
+// getRepoVersionBump resolves the bump of the PRs merged since the latest release of the repo, into master or into
+// the version branch of the latest release for a patch.
+func (r *Release) getRepoVersionBump(repo *Repo, patch bool) (*RepoVersionBump, error) {
+	latest, err := r.github.GetLatestRelease(repo.Owner, repo.Name, "", false)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return &RepoVersionBump{Repo: repo.Name}, nil
+	}
+
+	branch := "master"
+	if patch {
+		branch, err = r.getBranchFromTag(repo.Name, latest.Tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := r.github.CompareCommits(repo.Owner, repo.Name, latest.Tag, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRepoVersionBump(repo.Name, latest.Tag, branch, commits), nil
+}
+
+// getPatchPlan resolves the release information of a patch release, only the framework is released,
+// so the apps only need to upgrade it on their version branches.
+func (r *Release) getPatchPlan(tag string) (*ReleasePlan, error) {
+	framework := r.manifest.Framework()
+
+	releaseInfos, err := r.getPatchReleaseInformation(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var apps []*ReleasePlanRepo
+	for _, app := range r.manifest.Apps() {
+		branch, err := r.getBranchFromTag(app.Name, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies := []string{framework.Name}
+		upgrade := r.newUpgradeInformation(app.Name, branch, tag, r.getUpgradeCommands(dependencies, tag), r.getDependencyBranches(dependencies, releaseInfos))
+		apps = append(apps, newReleasePlanRepo(app, upgrade, releaseInfos[app.Name], false))
+	}
+	for _, repo := range r.manifest.AutoUpgrades() {
+		apps = append(apps, newReleasePlanRepo(repo, nil, nil, false))
+	}
+
+	return &ReleasePlan{
+		Command: "patch",
+		Tag:     tag,
+		Levels: [][]*ReleasePlanRepo{
+			{newReleasePlanRepo(framework, nil, releaseInfos[framework.Name], false)},
+			apps,
+		},
+	}, nil
+}
+
+// getUpgradeCommands returns the commands to upgrade the dependencies to the version.
+func (r *Release) getUpgradeCommands(dependencies []string, version string) []string {
+	var commands []string
+	for _, dependency := range dependencies {
+		commands = append(commands, fmt.Sprintf("go get %s@%s", r.module(dependency), version))
+	}
+
+	return commands
+}
+
+// getPreviewUpgradeCommands clones the dependencies at the branches they are released from and returns the
+// commands replacing the dependencies with the clones, the tags to upgrade to are only created in real mode.
+func (r *Release) getPreviewUpgradeCommands(upgrade *UpgradeInformation) ([]string, func(), error) {
+	var (
+		commands []string
+		dirs     []string
+	)
+	cleanup := func() {
+		for _, dir := range dirs {
+			_ = os.RemoveAll(dir)
+		}
+	}
+
+	dependencies := slices.Sorted(maps.Keys(upgrade.dependencies))
+	for _, dependency := range dependencies {
+		dir, err := makeRepoDir(dependency)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		dirs = append(dirs, dir)
+
+		if err := r.clone(dependency, dir, upgrade.dependencies[dependency]); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+
+		commands = append(commands, fmt.Sprintf("go mod edit -replace=%s=%s", r.module(dependency), dir))
+	}
+
+	return commands, cleanup, nil
+}
+
+func (r *Release) initState(command, tag string) error {
+	resume := r.ctx.OptionBool("resume")
+
+	if !r.real {
+		if resume {
+			return withExitCode(ExitCodeInvalidInput, fmt.Errorf("the --resume flag can only be used with the --real flag"))
+		}
+
+		return nil
+	}
+
+	if r.auditLog != nil {
+		r.auditLog.SetRelease(command, tag)
+	}
+
+	path := facades.App().StoragePath("release", fmt.Sprintf("%s-%s.json", command, tag))
+	if !resume {
+		r.state = NewReleaseState(path, command, tag)
+
+		// The changes made by the previous run are carried over, so they can still be rolled back.
+		if _, err := os.Stat(path); err == nil {
+			previous, err := LoadReleaseState(path)
+			if err != nil {
+				return err
 			}
 
-			// List PRs
-			prs, err := r.github.GetPullRequests(owner, repo, &github.PullRequestListOptions{
-				State: "open",
-			})
+			r.state.Created = previous.Created
+		}
+
+		return r.state.Save()
+	}
+
+	state, err := LoadReleaseState(path)
+	if err != nil {
+		return err
+	}
+	if state.Command != command || state.Tag != tag {
+		return withExitCode(ExitCodeInvalidInput, fmt.Errorf("release state %s belongs to %s %s, not %s %s", path, state.Command, state.Tag, command, tag))
+	}
+
+	r.state = state
+	color.Yellow().Println(fmt.Sprintf("Resume %s %s, completed steps: %s", command, tag, strings.Join(state.Steps, ", ")))
+
+	return nil
+}
+
+func (r *Release) isReleaseExist(repo string, tag string) (bool, error) {
+	release, err := r.github.GetReleaseByTag(r.owner(repo), repo, tag)
+	if err != nil {
+		return false, err
+	}
+
+	return release != nil, nil
+}
+
+// pushPR commits the changes in the dir to the branch, pushes it and creates the PR against the base branch,
+// an open PR with the same title is reused. It returns nil if there is nothing to commit, the kind is used in messages.
+// The commands producing the changes are recorded with the pushed branch.
+func (r *Release) pushPR(dir string, output io.Writer, repo, baseBranch, branch, title, kind string, commands []string) (*github.PullRequest, error) {
+	owner := r.owner(repo)
+
+	diffStat, err := r.git.DiffStat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes for %s: %w", repo, err)
+	}
+	if err := r.git.Commit(dir, title); err != nil {
+		if errors.Is(err, services.ErrNothingToCommit) {
+			_, _ = fmt.Fprintf(output, "%s/%s is already up to date\n", owner, repo)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to commit %s for %s: %w", kind, repo, err)
+	}
+	_, _ = fmt.Fprintln(output, diffStat)
+
+	if r.real {
+		err := r.git.Push(dir, branch, true)
+		r.audit(services.PlanActionPushBranch, repo, &services.PushBranchPayload{
+			Branch:   branch,
+			Base:     baseBranch,
+			Force:    true,
+			DiffStat: diffStat,
+			Commands: commands,
+		}, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to push %s branch for %s: %w", kind, repo, err)
+		}
+	} else {
+		_, _ = fmt.Fprintf(output, "Preview mode, skip pushing %s branch for %s\n", kind, repo)
+		r.plan.Add(services.PlanActionPushBranch, owner, repo, &services.PushBranchPayload{
+			Branch:   branch,
+			Base:     baseBranch,
+			Force:    true,
+			DiffStat: diffStat,
+			Commands: commands,
+		})
+	}
+
+	// Find existing PR
+	prs, err := r.github.GetPullRequests(owner, repo, &github.PullRequestListOptions{
+		State: "open",
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		if pr.GetTitle() == title {
+			return pr, nil
+		}
+	}
+
+	pr, err := r.github.CreatePullRequest(owner, repo, &github.NewPullRequest{
+		Title: convert.Pointer(title),
+		Head:  convert.Pointer(branch),
+		Base:  convert.Pointer(baseBranch),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordCreated(&CreatedResource{Kind: CreatedPullRequest, Repo: repo, Name: branch, ID: int64(pr.GetNumber())}); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (r *Release) pushBranch(repo, branch string) error {
+	return r.step("branch:"+repo, func() error {
+		return r.doPushBranch(repo, branch)
+	})
+}
+
+func (r *Release) doPushBranch(repo, branch string) error {
+	owner := r.owner(repo)
+
+	if err := r.ctx.Spinner(fmt.Sprintf("Pushing branch %s for %s...", branch, repo), console.SpinnerOption{
+		Action: func() error {
+			dir, err := makeRepoDir(repo)
 			if err != nil {
 				return err
 			}
+			defer func() {
+				_ = os.RemoveAll(dir)
+			}()
 
-			// Find existing PR
-			for _, p := range prs {
-				if *p.Title == prTitle {
-					pr = p
-					break
-				}
+			if err := r.clone(repo, dir, "master"); err != nil {
+				return err
+			}
+			if err := r.git.CreateBranch(dir, branch); err != nil {
+				return fmt.Errorf("failed to create branch %s for %s: %w", branch, repo, err)
 			}
 
-			// Create PR if not found
-			if pr == nil {
-				pr, err = r.github.CreatePullRequest(owner, repo, &github.NewPullRequest{
-					Title: convert.Pointer(prTitle),
-					Head:  convert.Pointer(upgradeBranch),
-					Base:  convert.Pointer(baseBranch),
+			if !r.real {
+				color.Yellow().Println(fmt.Sprintf("Preview mode, skip pushing branch %s for %s", branch, repo))
+				r.plan.Add(services.PlanActionPushBranch, owner, repo, &services.PushBranchPayload{
+					Branch: branch,
+					Base:   "master",
+					Force:  true,
 				})
-				if err != nil {
+
+				return nil
+			}
+
+			// Only a new branch is deleted by the rollback, the force push of an existing one can't be undone.
+			exists := true
+			if r.state != nil {
+				if exists, err = r.github.CheckBranchExists(owner, repo, branch); err != nil {
 					return err
 				}
 			}
 
-			return nil
+			err = r.git.Push(dir, branch, true)
+			r.audit(services.PlanActionPushBranch, repo, &services.PushBranchPayload{
+				Branch: branch,
+				Base:   "master",
+				Force:  true,
+			}, err)
+			if err != nil {
+				return fmt.Errorf("failed to push branch %s for %s: %w", branch, repo, err)
+			}
+
+			color.Green().Println(fmt.Sprintf("[%s/%s] Push %s branch success!", owner, repo, branch))
+
+			if exists {
+				return nil
+			}
+
+			return r.recordCreated(&CreatedResource{Kind: CreatedBranch, Repo: repo, Name: branch})
 		},
 	}); err != nil {
-		return nil, err
+		return err
 	}
 
-	return pr, nil
+	return nil
 }
 
-func (r *Release) divider() {
-	r.ctx.TwoColumnDetail("", "", '-')
+func (r *Release) newUpgradeInformation(repo, baseBranch, frameworkTag string, commands []string, dependencies map[string]string) *UpgradeInformation {
+	return &UpgradeInformation{
+		repo:         repo,
+		baseBranch:   baseBranch,
+		branch:       "auto-upgrade/" + frameworkTag,
+		commands:     commands,
+		dependencies: dependencies,
+		title:        fmt.Sprintf("chore: Upgrade framework to %s (auto)", frameworkTag),
+	}
 }
 
-func (r *Release) getBranchFromTag(repo, tag string) string {
-	tagArr := strings.Split(tag, ".")
-	branch := strings.Join(append(tagArr[:2], "x"), ".")
+// newAuditLog creates the audit log of the real mode, see services.AuditLog.
+func (r *Release) newAuditLog() *services.AuditLog {
+	path := r.auditLogPath
+	if path == "" {
+		path = facades.App().StoragePath("release", "audit.jsonl")
+	}
+
+	return services.NewAuditLog(path, r.operator)
+}
 
-	exist, err := r.github.CheckBranchExists(owner, repo, branch)
-	if err != nil {
-		panic(fmt.Errorf("failed to check branch %s exist for %s/%s: %w", branch, owner, repo, err))
+// module returns the module path of the repo, it's github.com/<owner>/<repo> if the repo isn't in the manifest.
+func (r *Release) module(repo string) string {
+	if manifestRepo := r.manifest.Repo(repo); manifestRepo != nil {
+		return manifestRepo.Module()
 	}
 
-	if !exist {
-		branch = "master"
+	return fmt.Sprintf("github.com/%s/%s", r.owner(repo), repo)
+}
+
+func (r *Release) owner(repo string) string {
+	return r.manifest.RepoOwner(repo)
+}
+
+func (r *Release) printPlan() {
+	r.divider()
+	color.Yellow().Println("The release plan:")
+	for i, action := range r.plan.Actions {
+		r.ctx.TwoColumnDetail(fmt.Sprintf("%d. %s/%s", i+1, action.Owner, action.Repo), string(action.Type))
+	}
+	r.divider()
+}
+
+// patchRepos returns the repos released by a patch release, the framework and the apps.
+func (r *Release) patchRepos() []*Repo {
+	repos := []*Repo{r.manifest.Framework()}
+	for _, app := range r.manifest.Apps() {
+		if !app.SkipRelease {
+			repos = append(repos, app)
+		}
 	}
 
-	return branch
+	return repos
 }
 
-func (r *Release) getPackagesReleaseInformation(tag string) (map[string]*ReleaseInformation, error) {
-	repoToReleaseInfo := make(map[string]*ReleaseInformation, 0)
-	allPackages := append(packages, "framework")
+func (r *Release) printReleaseInformation(releaseInfo *ReleaseInformation) {
+	r.divider()
+	color.Yellow().Println(fmt.Sprintf("Please check %s/%s information:", r.owner(releaseInfo.repo), releaseInfo.repo))
+	r.ctx.NewLine()
+
+	color.Black().Println(releaseInfo.notes.Name)
+	color.Black().Println(releaseInfo.notes.Body)
+
+	r.ctx.NewLine()
+
+	color.Black().Print("The latest tag is:             ")
+	color.Red().Println(releaseInfo.latestTag)
+
+	if len(releaseInfo.latestTagDecisions) > 0 {
+		color.Yellow().Println("The latest tag is resolved by:")
+		for _, decision := range releaseInfo.latestTagDecisions {
+			color.Black().Println("  - " + decision)
+		}
+	}
+
+	color.Black().Print("The tag to release is:         ")
+	color.Red().Println(releaseInfo.tag)
+
+	if len(releaseInfo.incompatibleChanges) > 0 {
+		color.Red().Println(fmt.Sprintf("The incompatible API changes against %s:", releaseInfo.latestTag))
+		for _, change := range releaseInfo.incompatibleChanges {
+			color.Black().Println("  - " + change)
+		}
+	}
+
+	if releaseInfo.currentTag != "" {
+		color.Black().Print("The current tag in code is:    ")
+		color.Red().Println(releaseInfo.currentTag)
+
+		if releaseInfo.currentTag != releaseInfo.tag {
+			r.ctx.NewLine()
+			color.Red().Println("---------------------- WARNING ----------------------")
+			color.Red().Println("The current tag is not the same as the tag to release")
+			color.Red().Println("-----------------------------------------------------")
+			color.Yellow().Println("A PR updating the Version constant will be created and merged before the release")
+		}
+	}
+
+	r.ctx.NewLine()
+}
+
+func (r *Release) printReleaseOrder(levels [][]*ReleasePlanRepo) {
+	r.divider()
+	color.Yellow().Println("The release order, repos in a level are released once the previous levels are released:")
+	for i, level := range levels {
+		var names []string
+		for _, repo := range level {
+			names = append(names, repo.Repo)
+		}
+
+		color.Black().Println(fmt.Sprintf("%d. %s", i+1, strings.Join(names, ", ")))
+	}
+	r.ctx.NewLine()
+}
+
+// printSummary prints whether the task passed for each repo.
+func (r *Release) printSummary(results []*RepoResult) {
+	r.divider()
+	for _, result := range results {
+		status := color.Green().Sprint("PASS")
+		if result.Err != nil {
+			status = color.Red().Sprint("FAIL")
+		}
+
+		r.ctx.TwoColumnDetail(fmt.Sprintf("%s/%s", r.owner(result.Repo), result.Repo), status)
+	}
+	r.divider()
+}
+
+// printVersionBump prints the bump of the repo and the PRs requiring it.
+func (r *Release) printVersionBump(versionBump *RepoVersionBump) {
+	owner := r.owner(versionBump.Repo)
+	if versionBump.LatestTag == "" {
+		color.Yellow().Println(fmt.Sprintf("[%s/%s] Skip, it has never been released", owner, versionBump.Repo))
+		return
+	}
+
+	color.Yellow().Println(fmt.Sprintf("[%s/%s] %s, %d PRs merged into %s since %s", owner, versionBump.Repo, versionBump.Bump, versionBump.Merged, versionBump.Branch, versionBump.LatestTag))
+	for _, reason := range versionBump.Reasons {
+		color.Black().Println("  - " + reason)
+	}
+}
+
+func (r *Release) refreshGoProxy() error {
+	var links []string
+
+	for _, repo := range r.manifest.Repos {
+		if repo.AutoUpgrade {
+			continue
+		}
+
+		links = append(links, fmt.Sprintf("curl https://proxy.golang.org/%s/@v/master.info", repo.Module()))
+	}
+
+	command := strings.Join(links, " && ")
+	if res := facades.Process().Quietly().WithSpinner("Refreshing Go Proxy...").Run(command); res.Failed() {
+		return fmt.Errorf("failed to refresh Go module proxy cache: %s", res.Error().Error())
+	}
+
+	color.Green().Println("Refreshed Go module proxy cache successfully")
+
+	return nil
+}
+
+// releaseAutoUpgradeRepo releases the repo that is upgraded by its own workflow, e.g. goravel/goravel.
+func (r *Release) releaseAutoUpgradeRepo(repo *ReleasePlanRepo, tag, branch string, releasedTags map[string]string) error {
+	if err := r.step("upgrade:"+repo.Repo, func() error {
+		return r.checkAutoUpgradePR(repo, tag, releasedTags)
+	}); err != nil {
+		return err
+	}
 
-	for _, repo := range allPackages {
-		releaseInfo, err := r.getPackageReleaseInformation(repo, tag)
+	if !repo.SkipRelease {
+		releaseInfo, err := r.getPackageReleaseInformation(repo.Repo, tag)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := r.confirmReleaseInformation(map[string]*ReleaseInformation{
+			repo.Repo: releaseInfo,
+		}); err != nil {
+			return err
+		}
+		if err := r.releaseRepo(releaseInfo); err != nil {
+			return err
 		}
+	}
+
+	return r.releaseBranches(repo, branch)
+}
 
-		repoToReleaseInfo[repo] = releaseInfo
+// releaseBranches pushes the version branch and sets it as the default branch according to the plan,
+// the branch is empty when releasing a patch version.
+func (r *Release) releaseBranches(repo *ReleasePlanRepo, branch string) error {
+	if branch == "" {
+		return nil
 	}
 
-	return repoToReleaseInfo, nil
+	if repo.PushBranch {
+		if err := r.pushBranch(repo.Repo, branch); err != nil {
+			return err
+		}
+	}
+
+	if repo.DefaultBranch {
+		if err := r.setDefaultBranch(repo.Repo, branch); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (r *Release) getPackageReleaseInformation(repo string, tag string) (*ReleaseInformation, error) {
-	var releaseInformation *ReleaseInformation
+// releaseLevel upgrades the dependencies of the repos in the level via upgrade PRs, then releases the repos
+// once all PRs are merged. All dependencies have been released in the previous levels at this point.
+func (r *Release) releaseLevel(repos []*ReleasePlanRepo, tag, branch string, releasedTags map[string]string) error {
+	var (
+		names    []string
+		upgrades []*UpgradeInformation
+	)
+	for _, repo := range repos {
+		if upgrade := repo.UpgradeInformation(); upgrade != nil {
+			names = append(names, repo.Repo)
+			upgrades = append(upgrades, upgrade)
+		}
+	}
 
-	if err := r.ctx.Spinner(fmt.Sprintf("Getting %s release information for %s...", repo, tag), console.SpinnerOption{
-		Action: func() error {
-			latestTag, err := r.getLatestTag(repo, tag)
+	if len(upgrades) > 0 {
+		if err := r.step("upgrade:"+strings.Join(names, ","), func() error {
+			repoToPR, err := r.createUpgradePRs(upgrades)
 			if err != nil {
 				return err
 			}
 
-			branch := r.getBranchFromTag(repo, tag)
-			notes, err := r.generateReleaseNotes(repo, tag, latestTag, branch)
-			if err != nil {
-				return err
+			if err := r.checkPRsMergeStatus(repoToPR); err != nil {
+				return fmt.Errorf("failed to check upgrade PRs merge status: %w", err)
 			}
 
-			releaseInformation = &ReleaseInformation{
-				notes:     notes,
-				tag:       tag,
-				latestTag: latestTag,
-				repo:      repo,
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, repo := range repos {
+		if repo.AutoUpgrade {
+			if err := r.releaseAutoUpgradeRepo(repo, tag, branch, releasedTags); err != nil {
+				return err
 			}
 
-			if repo == "installer" {
-				currentTag, err := r.getInstallerCurrentTag()
-				if err != nil {
-					return err
-				}
+			continue
+		}
 
-				releaseInformation.currentTag = currentTag
+		if releaseInfo := repo.ReleaseInformation(); releaseInfo != nil && !repo.SkipRelease {
+			if err := r.releaseRepo(releaseInfo); err != nil {
+				return err
 			}
+		}
 
-			if repo == "framework" {
-				currentTag, err := r.getFrameworkCurrentTag(r.getBranchFromTag("framework", tag))
-				if err != nil {
-					return err
-				}
+		if err := r.releaseBranches(repo, branch); err != nil {
+			return err
+		}
+	}
 
-				releaseInformation.currentTag = currentTag
-			}
+	return nil
+}
 
-			return nil
-		},
+// recordCreated records the resource created on GitHub in the state, so it can be undone by the rollback command.
+func (r *Release) recordCreated(resource *CreatedResource) error {
+	if r.state == nil {
+		return nil
+	}
+
+	return r.state.AddCreated(resource)
+}
+
+func (r *Release) releaseRepo(releaseInfo *ReleaseInformation) error {
+	if err := r.step("version:"+releaseInfo.repo, func() error {
+		return r.updateVersion(releaseInfo)
 	}); err != nil {
-		return nil, err
+		return err
+	}
+
+	return r.step("release:"+releaseInfo.repo, func() error {
+		if err := r.doReleaseRepo(releaseInfo); err != nil {
+			return err
+		}
+
+		if r.state != nil {
+			return r.state.SetTag(releaseInfo.repo, releaseInfo.tag)
+		}
+
+		return nil
+	})
+}
+
+func (r *Release) doReleaseRepo(releaseInfo *ReleaseInformation) error {
+	isExist, err := r.isReleaseExist(releaseInfo.repo, releaseInfo.tag)
+	if err != nil {
+		return err
+	}
+	if isExist {
+		color.Yellow().Println(fmt.Sprintf("%s/%s %s has already been released", r.owner(releaseInfo.repo), releaseInfo.repo, releaseInfo.tag))
+		return nil
+	}
+
+	if err := r.checkCIStatus(releaseInfo.repo, releaseInfo.branch); err != nil {
+		return err
+	}
+
+	if err := r.createRelease(releaseInfo.repo, releaseInfo.tag, releaseInfo.branch, releaseInfo.notes); err != nil {
+		return fmt.Errorf("failed to create release: %w", err)
 	}
 
-	return releaseInformation, nil
+	r.releaseSuccess(releaseInfo.repo, releaseInfo.tag)
+
+	return nil
+}
+
+func (r *Release) releaseMajorSuccess(tag string) {
+	r.ctx.NewLine()
+	color.Green().Println(fmt.Sprintf("Release %s success!", tag))
+	color.Yellow().Println("The rest jobs:")
+	color.Black().Println(fmt.Sprintf("1. Smoke test a new project installed via goravel/installer: ./artisan smoke %s", tag))
+	color.Black().Println("2. Modify the support policy: https://www.goravel.dev/prologue/releases.html#support-policy")
+}
+
+// releasePlanSuccess prints the success message of the command the plan is resolved for.
+func (r *Release) releasePlanSuccess(plan *ReleasePlan) {
+	switch plan.Command {
+	case "major":
+		r.releaseMajorSuccess(plan.Tag)
+	case "rc":
+		r.releaseRCSuccess(plan.Tag)
+	default:
+		r.releasePatchSuccess(plan.Tag)
+	}
 }
 
-func (r *Release) getFrameworkCurrentTag(branch string) (string, error) {
-	return r.getCurrentTag("framework", fmt.Sprintf("https://raw.githubusercontent.com/goravel/framework/refs/heads/%s/support/constant.go", branch))
+func (r *Release) releaseRCSuccess(tag string) {
+	r.ctx.NewLine()
+	color.Green().Println(fmt.Sprintf("Release %s success!", tag))
+	color.Yellow().Println("The rest jobs:")
+	color.Black().Println(fmt.Sprintf("1. Smoke test a new project installed via goravel/installer: ./artisan smoke %s", tag))
+	color.Black().Println("2. Release the final version via the major command once the prerelease is verified")
 }
 
-func (r *Release) getInstallerCurrentTag() (string, error) {
-	return r.getCurrentTag("installer", "https://raw.githubusercontent.com/goravel/installer/refs/heads/master/support/constant.go")
+func (r *Release) releasePatchSuccess(frameworkTag string) {
+	framework := r.manifest.Framework()
+
+	r.ctx.NewLine()
+	color.Green().Println(fmt.Sprintf("Release %s/%s %s success!", framework.Owner, framework.Name, frameworkTag))
 }
 
-func (r *Release) getCurrentTag(repo, url string) (string, error) {
-	response, err := facades.Http().Get(url)
-	if err != nil {
-		return "", err
-	}
+func (r *Release) releaseSuccess(repo, tagName string) {
+	owner := r.owner(repo)
 
-	body, err := response.Body()
-	if err != nil {
-		return "", err
-	}
+	color.Green().Println(fmt.Sprintf("[%s/%s] Release %s success!", owner, repo, tagName))
+	color.Green().Println(fmt.Sprintf("Release link: https://github.com/%s/%s/releases/tag/%s", owner, repo, tagName))
+}
 
-	// Extract version from body using regex
-	versionRegex := regexp.MustCompile(`Version\s*.*?=\s*"([^"]+)"`)
-	matches := versionRegex.FindStringSubmatch(body)
-	var currentVersion string
-	if len(matches) > 1 {
-		currentVersion = matches[1]
-	} else {
-		return "", fmt.Errorf("could not extract goravel/%s version from code", repo)
+// resolveVersion returns the version of the tag argument, it's suggested from the merged PRs if the tag is auto,
+// see getNextVersion. The major command refuses the suggestion if only fixes are merged into master.
+func (r *Release) resolveVersion(patch bool) (*services.Version, error) {
+	if tag := r.ctx.ArgumentString("tag"); tag != "auto" {
+		return parseVersion(tag)
 	}
 
-	return currentVersion, nil
-}
+	if r.github == nil {
+		r.github = services.NewGithubImpl(true, nil, nil)
+	}
 
-func (r *Release) generateReleaseNotes(repo, tag, previousTag, branch string) (*github.RepositoryReleaseNotes, error) {
-	notes, err := r.github.GenerateReleaseNotes(owner, repo, &github.GenerateNotesOptions{
-		TagName:         tag,
-		PreviousTagName: convert.Pointer(previousTag),
-		TargetCommitish: convert.Pointer(branch),
-	})
+	version, bump, err := r.getNextVersion(patch)
 	if err != nil {
 		return nil, err
 	}
-	if notes == nil {
-		return nil, fmt.Errorf("failed to generate release notes, notes is nil")
+	if !patch && bump == BumpPatch {
+		return nil, withExitCode(ExitCodeRefused, fmt.Errorf("no feature or breaking PR is merged into master since the latest release, release a patch via the patch command"))
 	}
 
-	return notes, nil
+	if !r.confirm(fmt.Sprintf("Release the suggested version %s?", version), true) {
+		return nil, withExitCode(ExitCodeRefused, fmt.Errorf("the suggested version %s is not confirmed", version))
+	}
+
+	return version, nil
 }
 
-func (r *Release) getLatestTag(repo, tag string) (string, error) {
-	latestRelease, err := r.github.GetLatestRelease(owner, repo, tag)
-	if err != nil {
-		return "", err
-	}
+// rollbackState undoes the created resources of the state from the newest, it returns the number of resources kept.
+// The state is only updated in real mode: it's deleted once everything is undone, or saved with the kept resources.
+func (r *Release) rollbackState(state *ReleaseState) (int, error) {
+	color.Yellow().Println(fmt.Sprintf("Roll back %s %s, %d changes recorded in %s", state.Command, state.Tag, len(state.Created), state.Path()))
 
-	if latestRelease == nil {
-		return "", nil
+	var (
+		kept    []*CreatedResource
+		undoErr error
+	)
+	for i := len(state.Created) - 1; i >= 0; i-- {
+		resource := state.Created[i]
+
+		undone, err := r.undo(state, resource)
+		if err != nil {
+			// The older resources may depend on the failed one, e.g. a branch set as the default branch.
+			kept = append(slices.Clone(state.Created[:i+1]), kept...)
+			undoErr = err
+
+			break
+		}
+		if !undone {
+			kept = append([]*CreatedResource{resource}, kept...)
+		}
 	}
 
-	if latestRelease.TagName == nil {
-		return "", fmt.Errorf("latest release tag name is nil for %s/%s", owner, repo)
+	if !r.real {
+		return len(kept), undoErr
 	}
 
-	return *latestRelease.TagName, nil
-}
+	if len(kept) == 0 {
+		if err := state.Delete(); err != nil {
+			return 0, err
+		}
 
-func (r *Release) isReleaseExist(repo string, tag string) (bool, error) {
-	releases, err := r.github.GetReleases(owner, repo, &github.ListOptions{
-		Page:    1,
-		PerPage: 10,
-	})
-	if err != nil {
-		return false, err
+		return 0, undoErr
 	}
 
-	for _, release := range releases {
-		if release.TagName != nil && *release.TagName == tag {
-			return true, nil
-		}
+	state.Created = kept
+	if err := state.Save(); err != nil {
+		return 0, errors.Join(undoErr, err)
 	}
 
-	return false, nil
+	return len(kept), undoErr
 }
 
-func (r *Release) pushBranch(repo, branch string) error {
-	defer func() {
-		_ = facades.Process().Run(fmt.Sprintf("rm -rf %s", repo))
-	}()
-
-	if err := r.ctx.Spinner(fmt.Sprintf("Pushing branch %s for %s...", branch, repo), console.SpinnerOption{
-		Action: func() error {
-			if !r.real {
-				color.Yellow().Println(fmt.Sprintf("Preview mode, skip pushing branch %s for %s", branch, repo))
-				return nil
-			}
+// runInDir runs the command quietly in the dir, the output of the command is written to output.
+func (r *Release) runInDir(dir string, output io.Writer, command string) process.Result {
+	return facades.Process().Path(dir).Quietly().OnOutput(func(_ process.OutputType, line []byte) {
+		_, _ = fmt.Fprintln(output, string(line))
+	}).Run(command)
+}
 
-			command := fmt.Sprintf(`rm -rf %s && git clone git@github.com:%s/%s.git && 
-cd %s && git checkout master && git branch -D %s 2>/dev/null || true && git checkout -b %s && git push origin %s -f`,
-				repo, owner, repo, repo, branch, branch, branch)
-			if res := facades.Process().Run(command); res.Failed() {
-				return fmt.Errorf("failed to push upgrade branch for %s: %w", repo, res.Error())
-			}
+// runRepoTasks runs the task against the repos in the worker pool, then prints the aggregated output of
+// every repo and a summary of the results. The errors of all failed repos are joined and returned.
+func (r *Release) runRepoTasks(message string, repos []string, task RepoTask) error {
+	if len(repos) == 0 {
+		return nil
+	}
 
-			color.Green().Println(fmt.Sprintf("[%s/%s] Push %s branch success!", owner, repo, branch))
+	var results []*RepoResult
+	if err := r.ctx.Spinner(message, console.SpinnerOption{
+		Action: func() error {
+			results = runInPool(r.concurrency, repos, task)
 
 			return nil
 		},
@@ -638,273 +2451,354 @@ cd %s && git checkout master && git branch -D %s 2>/dev/null || true && git chec
 		return err
 	}
 
-	return nil
-}
-
-func (r *Release) printReleaseInformation(releaseInfo *ReleaseInformation) {
-	r.divider()
-	color.Yellow().Println(fmt.Sprintf("Please check %s/%s information:", owner, releaseInfo.repo))
-	r.ctx.NewLine()
-
-	color.Black().Println(releaseInfo.notes.Name)
-	color.Black().Println(releaseInfo.notes.Body)
-
-	r.ctx.NewLine()
-
-	color.Black().Print("The latest tag is:             ")
-	color.Red().Println(releaseInfo.latestTag)
-
-	color.Black().Print("The tag to release is:         ")
-	color.Red().Println(releaseInfo.tag)
-
-	if releaseInfo.currentTag != "" {
-		color.Black().Print("The current tag in code is:    ")
-		color.Red().Println(releaseInfo.currentTag)
-
-		if releaseInfo.currentTag != releaseInfo.tag {
-			r.ctx.NewLine()
-			color.Red().Println("---------------------- WARNING ----------------------")
-			color.Red().Println("The current tag is not the same as the tag to release")
-			color.Red().Println("-----------------------------------------------------")
+	for _, result := range results {
+		if result.Output == "" {
+			continue
 		}
+
+		color.Yellow().Println(fmt.Sprintf("[%s/%s] Output:", r.owner(result.Repo), result.Repo))
+		color.Black().Print(result.Output)
 	}
 
-	r.ctx.NewLine()
+	r.printSummary(results)
+
+	return poolError(results)
 }
 
-func (r *Release) refreshGoProxy() error {
-	allPackages := append(packages, "framework", "example")
-	var links []string
+// savePlan prints the actions recorded in preview mode and saves them as JSON for review before the real release.
+func (r *Release) savePlan(command, tag string) error {
+	if r.plan == nil {
+		return nil
+	}
 
-	for _, pkg := range allPackages {
-		links = append(links, fmt.Sprintf("curl https://proxy.golang.org/github.com/goravel/%s/@v/master.info", pkg))
+	path := r.ctx.Option("plan")
+	if path == "" {
+		path = facades.App().StoragePath("release", fmt.Sprintf("%s-%s-plan.json", command, tag))
 	}
 
-	command := strings.Join(links, " && ")
-	if res := facades.Process().Quietly().WithSpinner("Refreshing Go Proxy...").Run(command); res.Failed() {
-		return fmt.Errorf("failed to refresh Go module proxy cache: %s", res.Error().Error())
+	r.printPlan()
+
+	if err := r.plan.Save(path); err != nil {
+		return err
 	}
 
-	color.Green().Println("Refreshed Go module proxy cache successfully")
+	color.Green().Println(fmt.Sprintf("The release plan has been saved to %s", path))
 
 	return nil
 }
 
-func (r *Release) releaseExample(tag, branch string) error {
-	repo := "example"
-	examplePR, err := r.createUpgradePRForExample(tag, []string{
-		fmt.Sprintf("go get github.com/goravel/framework@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/gin@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/fiber@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/s3@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/oss@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/cos@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/minio@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/postgres@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/mysql@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/sqlserver@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/sqlite@%s", tag),
-		fmt.Sprintf("go get github.com/goravel/redis@%s", tag),
+func (r *Release) setDefaultBranch(repo, branch string) error {
+	return r.step("default-branch:"+repo, func() error {
+		return r.doSetDefaultBranch(repo, branch)
 	})
-	if err != nil {
-		return err
-	}
-
-	if err := r.checkPRsMergeStatus(map[string]*github.PullRequest{
-		repo: examplePR,
-	}); err != nil {
-		return fmt.Errorf("failed to check upgrade PRs merge status: %w", err)
-	}
+}
 
-	if branch != "" {
-		if err := r.pushBranch("example", branch); err != nil {
-			return err
-		}
+func (r *Release) doSetDefaultBranch(repo, branch string) error {
+	owner := r.owner(repo)
 
-		if err := r.setDefaultBranch("example", branch); err != nil {
+	var previous string
+	if r.state != nil {
+		var err error
+		if previous, err = r.github.GetDefaultBranch(owner, repo); err != nil {
 			return err
 		}
 	}
 
-	return nil
-}
-
-func (r *Release) releaseFramework(branch string, releaseInfo *ReleaseInformation) error {
-	if err := r.releaseRepo(releaseInfo); err != nil {
-		return err
+	if err := r.github.SetDefaultBranch(owner, repo, branch); err != nil {
+		return fmt.Errorf("failed to set default branch %s for %s/%s: %w", branch, owner, repo, err)
 	}
 
-	if branch != "" {
-		if err := r.pushBranch("framework", branch); err != nil {
-			return err
-		}
+	color.Green().Println(fmt.Sprintf("[%s/%s] Set default branch to %s success!", owner, repo, branch))
+
+	if previous == "" || previous == branch {
+		return nil
 	}
 
-	return nil
+	return r.recordCreated(&CreatedResource{Kind: CreatedDefaultBranch, Repo: repo, Name: branch, Previous: previous})
 }
 
-func (r *Release) releaseGoravel(tag, branch string) error {
-	repo := "goravel"
-
-	if !r.checkGoravelAutoUpgradePRMergeStatus(r.ctx) {
-		return fmt.Errorf("failed to check goravel auto upgrade PR merge status")
+// setup reads the common flags and creates the services, the mutating actions are recorded
+// in the plan instead of being executed in preview mode.
+func (r *Release) setup() {
+	r.concurrency = r.ctx.OptionInt("concurrency")
+	r.real = r.ctx.OptionBool("real")
+	r.autoMerge = r.ctx.OptionBool("auto-merge")
+	r.mergeMethod = r.ctx.Option("merge-method")
+	if r.mergeMethod == "" {
+		r.mergeMethod = "squash"
 	}
-
-	goravelReleaseInfo, err := r.getPackageReleaseInformation(repo, tag)
-	if err != nil {
-		return err
+	r.mergeTimeout = time.Duration(r.ctx.OptionInt("merge-timeout")) * time.Minute
+	if r.mergeTimeout == 0 {
+		r.mergeTimeout = defaultMergeTimeout
 	}
-	if err := r.confirmReleaseInformation(map[string]*ReleaseInformation{
-		repo: goravelReleaseInfo,
-	}); err != nil {
-		return err
+	if r.real {
+		r.auditLog = r.newAuditLog()
+	} else {
+		r.plan = services.NewPlan()
+	}
+	r.apiDiff = services.NewAPIDiffImpl()
+	r.git = services.NewGitImpl()
+	r.github = services.NewGithubImpl(r.real, r.plan, r.auditLog)
+	r.goProxy = services.NewGoProxyImpl(r.proxyURL, r.sumDBURL)
+}
+
+// step runs the action unless it has been completed by a previous run, then records it as completed.
+func (r *Release) step(name string, action func() error) error {
+	if r.state != nil && r.state.IsCompleted(name) {
+		color.Yellow().Println(fmt.Sprintf("Step %s has been completed, skip", name))
+		return nil
 	}
-	if err := r.releaseRepo(goravelReleaseInfo); err != nil {
+
+	if err := action(); err != nil {
 		return err
 	}
 
-	if branch != "" {
-		if err := r.pushBranch(repo, branch); err != nil {
-			return err
-		}
-		if err := r.setDefaultBranch(repo, branch); err != nil {
-			return err
-		}
+	if r.state != nil {
+		return r.state.Complete(name)
 	}
 
 	return nil
 }
 
-func (r *Release) releasePackages(packagesReleaseInfo map[string]*ReleaseInformation, tag, branch string) error {
-	packageToPR, err := r.createUpgradePRsForPackages(tag)
-	if err != nil {
-		return err
-	}
+// undo undoes the resource once confirmed, it returns false if the resource is kept. The resources changed since
+// the release, e.g. a merged PR or a default branch set to another branch, are left alone and treated as undone.
+func (r *Release) undo(state *ReleaseState, resource *CreatedResource) (bool, error) {
+	var (
+		owner = r.owner(resource.Repo)
+		name  = fmt.Sprintf("%s/%s", owner, resource.Repo)
+	)
 
-	if err := r.checkPRsMergeStatus(packageToPR); err != nil {
-		return fmt.Errorf("failed to check upgrade PRs merge status: %w", err)
+	// Nothing is changed in preview mode, so every step is listed without asking.
+	confirm := func(question string) bool {
+		return !r.real || r.confirm(question, false)
 	}
 
-	for pkg, releaseInfo := range packagesReleaseInfo {
-		// Skip framework, already released
-		if pkg == "framework" {
-			continue
+	switch resource.Kind {
+	case CreatedPullRequest:
+		pr, err := r.github.GetPullRequest(owner, resource.Repo, int(resource.ID))
+		if err != nil {
+			return false, err
 		}
-
-		if err := r.releaseRepo(releaseInfo); err != nil {
-			return err
+		if pr.GetState() != "open" {
+			color.Yellow().Println(fmt.Sprintf("[%s] Pull request #%d has been closed or merged, skip", name, resource.ID))
+			return true, nil
 		}
-
-		if releaseInfo.repo == "goravel-lite" {
-			if branch != "" {
-				if err := r.pushBranch(releaseInfo.repo, branch); err != nil {
-					return err
-				}
-				if err := r.setDefaultBranch(releaseInfo.repo, branch); err != nil {
-					return err
-				}
+		if !confirm(fmt.Sprintf("Close pull request #%d %s of %s?", resource.ID, resource.Name, name)) {
+			return false, nil
+		}
+		if err := r.github.ClosePullRequest(owner, resource.Repo, int(resource.ID)); err != nil {
+			return false, err
+		}
+	case CreatedDefaultBranch:
+		current, err := r.github.GetDefaultBranch(owner, resource.Repo)
+		if err != nil {
+			return false, err
+		}
+		if current != resource.Name {
+			color.Yellow().Println(fmt.Sprintf("[%s] The default branch has been changed to %s since the release, skip", name, current))
+			return true, nil
+		}
+		if !confirm(fmt.Sprintf("Reset the default branch of %s from %s to %s?", name, resource.Name, resource.Previous)) {
+			return false, nil
+		}
+		if err := r.github.SetDefaultBranch(owner, resource.Repo, resource.Previous); err != nil {
+			return false, fmt.Errorf("failed to reset default branch %s for %s: %w", resource.Previous, name, err)
+		}
+	case CreatedBranch:
+		if !confirm(fmt.Sprintf("Delete branch %s of %s?", resource.Name, name)) {
+			return false, nil
+		}
+		if err := r.github.DeleteBranch(owner, resource.Repo, resource.Name); err != nil {
+			return false, err
+		}
+	case CreatedRelease:
+		// The release ID is cleared once the release is deleted, so only the tag is left to the next rollback.
+		if resource.ID != 0 {
+			if !confirm(fmt.Sprintf("Delete release %s of %s?", resource.Name, name)) {
+				return false, nil
 			}
-		} else {
-			if branch != "" {
-				if err := r.pushBranch(releaseInfo.repo, branch); err != nil {
-					return err
+			if err := r.github.DeleteRelease(owner, resource.Repo, resource.ID); err != nil {
+				return false, err
+			}
+			if r.real {
+				resource.ID = 0
+				if err := state.Save(); err != nil {
+					return false, err
 				}
 			}
 		}
+		if !confirm(fmt.Sprintf("Delete tag %s of %s?", resource.Name, name)) {
+			return false, nil
+		}
+		if err := r.github.DeleteTag(owner, resource.Repo, resource.Name); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("unknown change %s of %s", resource.Kind, name)
 	}
 
-	return nil
+	if r.real {
+		color.Green().Println(fmt.Sprintf("[%s] Undo %s %s success!", name, resource.Kind, resource.Name))
+	}
+
+	return true, nil
 }
 
-func (r *Release) releaseRepo(releaseInfo *ReleaseInformation) error {
-	isExist, err := r.isReleaseExist(releaseInfo.repo, releaseInfo.tag)
-	if err != nil {
-		return err
-	}
-	if isExist {
-		color.Yellow().Println(fmt.Sprintf("%s/%s %s has already been released", owner, releaseInfo.repo, releaseInfo.tag))
+// updateVersion makes the Version constant the same as the tag to release via a PR, and waits for the PR
+// to be merged, so the released code reports the right version. Only the repos declaring version_file have it.
+func (r *Release) updateVersion(releaseInfo *ReleaseInformation) error {
+	repo := r.manifest.Repo(releaseInfo.repo)
+	if repo == nil || repo.VersionFile == "" || releaseInfo.currentTag == releaseInfo.tag {
 		return nil
 	}
 
-	if err := r.createRelease(releaseInfo.repo, releaseInfo.tag, releaseInfo.notes); err != nil {
-		return fmt.Errorf("failed to create release: %w", err)
+	var pr *github.PullRequest
+	if err := r.runRepoTasks(fmt.Sprintf("Creating version PR for %s...", repo.Name), []string{repo.Name}, func(_, dir string, output io.Writer) error {
+		var err error
+		pr, err = r.createVersionPR(dir, output, releaseInfo, repo.VersionFile)
+
+		return err
+	}); err != nil {
+		return err
 	}
 
-	r.releaseSuccess(releaseInfo.repo, releaseInfo.tag)
+	if pr != nil {
+		if err := r.checkPRsMergeStatus(map[string]*github.PullRequest{repo.Name: pr}); err != nil {
+			return fmt.Errorf("failed to check version PR merge status: %w", err)
+		}
+	}
+
+	releaseInfo.currentTag = releaseInfo.tag
 
 	return nil
 }
 
-func (r *Release) releaseMajorSuccess(tag string) {
-	r.ctx.NewLine()
-	color.Green().Println(fmt.Sprintf("Release %s success!", tag))
-	color.Yellow().Println("The rest jobs:")
-	color.Black().Println("1. Install the new version via goravel/installer and test the project works fine")
-	color.Black().Println("2. Modify the support policy: https://www.goravel.dev/prologue/releases.html#support-policy")
+// version parses the tag argument up front, so an invalid tag is rejected before anything is released.
+func (r *Release) version() (*services.Version, error) {
+	return parseVersion(r.ctx.ArgumentString("tag"))
 }
 
-func (r *Release) releasePatchSuccess(frameworkTag string) {
-	r.ctx.NewLine()
-	color.Green().Println(fmt.Sprintf("Release goravel/framework %s success!", frameworkTag))
+// parseVersion parses the tag, an invalid tag is an invalid input.
+func parseVersion(tag string) (*services.Version, error) {
+	version, err := services.ParseVersion(tag)
+	if err != nil {
+		return nil, withExitCode(ExitCodeInvalidInput, err)
+	}
+
+	return version, nil
 }
 
-func (r *Release) releaseSuccess(repo, tagName string) {
-	color.Green().Println(fmt.Sprintf("[%s/%s] Release %s success!", owner, repo, tagName))
-	color.Green().Println(fmt.Sprintf("Release link: https://github.com/%s/%s/releases/tag/%s", owner, repo, tagName))
+// verifyRelease verifies the modules released by the plan, it's skipped in preview mode given no tag is created.
+func (r *Release) verifyRelease(plan *ReleasePlan) error {
+	if !r.real {
+		return nil
+	}
+
+	return r.verifyModules(plan.ReleasedTags(), 0)
 }
 
-func (r *Release) setDefaultBranch(repo, branch string) error {
-	if err := r.github.SetDefaultBranch(owner, repo, branch); err != nil {
-		return fmt.Errorf("failed to set default branch %s for %s/%s: %w", branch, owner, repo, err)
+// verifyModules checks the Go module proxy serves the .info, .mod and .zip of the released tags, keyed by repo, and
+// their hashes match the checksum database. The apps are skipped given they aren't depended on as modules. A module
+// not fetchable yet is retried with the interval doubling up to maxPollInterval, a checksum mismatch isn't retried.
+func (r *Release) verifyModules(repoToTag map[string]string, retries int) error {
+	if retries <= 0 {
+		retries = defaultVerifyRetries
 	}
 
-	color.Green().Println(fmt.Sprintf("[%s/%s] Set default branch to %s success!", owner, repo, branch))
+	interval := r.pollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
 
-	return nil
-}
+	var pending []string
+	for _, repo := range slices.Sorted(maps.Keys(repoToTag)) {
+		if manifestRepo := r.manifest.Repo(repo); manifestRepo != nil && manifestRepo.Role != RoleApp {
+			pending = append(pending, repo)
+		}
+	}
 
-func (r *Release) testInSubPackages(branch string) error {
-	if !r.ctx.Confirm("Did you test in sub-packages?") {
-		// Test example first given there is a random error when testing for a long time.
-		packagesWithExample := append([]string{"example"}, packages...)
-		for _, pkg := range packagesWithExample {
-			if err := r.testInSubPackage(pkg, branch); err != nil {
-				return err
+	var (
+		errs    = make(map[string]error)
+		results = make(map[string]*RepoResult)
+	)
+	for attempt := 1; ; attempt++ {
+		var waiting []string
+		for _, repo := range pending {
+			path, tag := r.manifest.Repo(repo).Module(), repoToTag[repo]
+			switch err := r.goProxy.Verify(path, tag); {
+			case err == nil:
+				color.Green().Println(fmt.Sprintf("[%s/%s] %s@%s is fetchable", r.owner(repo), repo, path, tag))
+				results[repo] = &RepoResult{Repo: repo}
+			case errors.Is(err, services.ErrChecksumMismatch):
+				color.Red().Println(fmt.Sprintf("[%s/%s] %v", r.owner(repo), repo, err))
+				results[repo] = &RepoResult{Repo: repo, Err: withExitCode(ExitCodeChecksFailed, err)}
+			default:
+				errs[repo] = err
+				waiting = append(waiting, repo)
 			}
 		}
+
+		pending = waiting
+		if len(pending) == 0 || attempt >= retries {
+			break
+		}
+
+		color.Yellow().Println(fmt.Sprintf("Waiting for the Go module proxy to serve %s, check again in %s...", strings.Join(pending, ", "), interval))
+		time.Sleep(interval)
+		interval = min(interval*2, maxPollInterval)
+	}
+
+	for _, repo := range pending {
+		color.Red().Println(fmt.Sprintf("[%s/%s] %v", r.owner(repo), repo, errs[repo]))
+		results[repo] = &RepoResult{
+			Repo: repo,
+			Err:  withExitCode(ExitCodePending, fmt.Errorf("%s@%s is not fetchable after %d attempts: %w", r.manifest.Repo(repo).Module(), repoToTag[repo], retries, errs[repo])),
+		}
 	}
 
-	return nil
+	var sortedResults []*RepoResult
+	for _, repo := range slices.Sorted(maps.Keys(results)) {
+		sortedResults = append(sortedResults, results[repo])
+	}
+	r.printSummary(sortedResults)
+
+	return poolError(sortedResults)
 }
 
-func (r *Release) testInSubPackage(pkg, branch string) error {
-	defer func() {
-		_ = facades.Process().Run(fmt.Sprintf("rm -rf %s", pkg))
-	}()
+func (r *Release) testInSubPackages(branch string) error {
+	if r.confirm("Did you test in sub-packages?", false) {
+		return nil
+	}
+
+	// Schedule the dependents (e.g. example) first given there is a random error when testing for a long time.
+	repos := repoNames(append(r.manifest.Dependents(), r.manifest.Packages()...))
 
-	packages := fmt.Sprintf("go get github.com/goravel/framework@%s && ", branch)
-	if pkg == "example" {
-		packages = fmt.Sprintf(`go get github.com/goravel/gin@%s && 
-				go get github.com/goravel/fiber@%s && 
-				go get github.com/goravel/s3@%s && 
-				go get github.com/goravel/oss@%s && 
-				go get github.com/goravel/cos@%s && 
-				go get github.com/goravel/minio@%s && 
-				go get github.com/goravel/postgres@%s && 
-				go get github.com/goravel/mysql@%s && 
-				go get github.com/goravel/sqlserver@%s && 
-				go get github.com/goravel/sqlite@%s && 
-				go get github.com/goravel/redis@%s && `, branch, branch, branch, branch, branch, branch, branch, branch, branch, branch, branch)
+	return withExitCode(ExitCodeChecksFailed, r.runRepoTasks(fmt.Sprintf("Testing in %s...", strings.Join(repos, ", ")), repos, func(repo, dir string, output io.Writer) error {
+		return r.testInSubPackage(dir, output, repo, branch)
+	}))
+}
+
+// testInSubPackage tests the package cloned in the dir against the branch, the output of the tests is written to output.
+func (r *Release) testInSubPackage(dir string, output io.Writer, pkg, branch string) error {
+	var dependencies []string
+	if repo := r.manifest.Repo(pkg); repo != nil {
+		dependencies = repo.Dependencies
+	}
+
+	var upgradeCommands string
+	for _, command := range r.getUpgradeCommands(dependencies, branch) {
+		upgradeCommands += command + " && "
+	}
+
+	if err := r.clone(pkg, dir, branch); err != nil {
+		return err
 	}
 
 	// Using `-p 1` to avoid random test failure caused in example package, which may be caused by too many test cases running in parallel.
-	initCommand := fmt.Sprintf(`rm -rf %s && git clone git@github.com:goravel/%s.git && 
-				cd %s && git checkout %s && %s go mod tidy && cp .env.example .env 2>/dev/null || true && go test -p 1 ./...`, pkg, pkg, pkg, branch, packages)
-	if res := facades.Process().Run(initCommand); res.Failed() {
+	testCommand := fmt.Sprintf(`%s go mod tidy && (cp .env.example .env 2>/dev/null || true) && go test -p 1 ./...`, upgradeCommands)
+	if res := r.runInDir(dir, output, testCommand); res.Failed() {
 		return fmt.Errorf("failed to test in %s: %w", pkg, res.Error())
 	}
 
-	color.Green().Println(fmt.Sprintf("Testing in %s success!", pkg))
-
 	return nil
 }
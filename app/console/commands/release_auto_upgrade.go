@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+	"golang.org/x/mod/modfile"
+)
+
+// findAutoUpgradePR returns the newest auto upgrade PR of the tag among the PRs listed newest first. The PR is
+// matched by its title upgrading to the tag, e.g. chore: Upgrade framework to v1.17.0, or by its head branch
+// containing the tag, e.g. upgrade/v1.17.0. The PRs closed without being merged are skipped.
+func findAutoUpgradePR(prs []*github.PullRequest, tag string) *github.PullRequest {
+	// The tag shouldn't match a longer version, e.g. v1.17.0 doesn't match v1.17.0-rc.1 or v1.17.01.
+	tagRegexp := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(tag) + `($|[^\w.-])`)
+
+	for _, pr := range prs {
+		if pr.GetState() == "closed" && !pr.GetMerged() {
+			continue
+		}
+
+		title := pr.GetTitle()
+		if (strings.Contains(strings.ToLower(title), "upgrade") && tagRegexp.MatchString(title)) || tagRegexp.MatchString(pr.GetHead().GetRef()) {
+			return pr
+		}
+	}
+
+	return nil
+}
+
+// goModMismatches returns the requires of the go.mod that are not the released tags, e.g. github.com/goravel/gin
+// v1.16.0, want v1.17.0, sorted by module. The modules not required are skipped, except the framework module.
+func goModMismatches(repo string, content []byte, frameworkModule string, releasedTags map[string]string) ([]string, error) {
+	file, err := modfile.ParseLax(repo+"/go.mod", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod of %s: %w", repo, err)
+	}
+
+	requires := make(map[string]string)
+	for _, require := range file.Require {
+		requires[require.Mod.Path] = require.Mod.Version
+	}
+
+	var mismatches []string
+	for module, tag := range releasedTags {
+		version, required := requires[module]
+		switch {
+		case !required && module == frameworkModule:
+			mismatches = append(mismatches, fmt.Sprintf("%s is not required, want %s", module, tag))
+		case required && version != tag:
+			mismatches = append(mismatches, fmt.Sprintf("%s %s, want %s", module, version, tag))
+		}
+	}
+	slices.Sort(mismatches)
+
+	return mismatches, nil
+}
+
+// diffSummary summarizes the files changed by a PR, e.g. go.mod +1 -1.
+func diffSummary(files []*github.CommitFile) []string {
+	var summary []string
+	for _, file := range files {
+		summary = append(summary, fmt.Sprintf("%s +%d -%d", file.GetFilename(), file.GetAdditions(), file.GetDeletions()))
+	}
+
+	return summary
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/goravel/framework/support/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAutoUpgradePR(t *testing.T) {
+	pr := func(number int, title, head, state string, merged bool) *github.PullRequest {
+		return &github.PullRequest{
+			Number: convert.Pointer(number),
+			Title:  convert.Pointer(title),
+			Head:   &github.PullRequestBranch{Ref: convert.Pointer(head)},
+			State:  convert.Pointer(state),
+			Merged: convert.Pointer(merged),
+		}
+	}
+
+	tests := []struct {
+		name string
+		prs  []*github.PullRequest
+		want int
+	}{
+		{
+			name: "by title",
+			prs: []*github.PullRequest{
+				pr(3, "feat: Add a new driver", "feat/driver", "open", false),
+				pr(2, "chore: Upgrade framework to v1.17.0", "renovate/framework", "open", false),
+			},
+			want: 2,
+		},
+		{
+			name: "by head branch",
+			prs: []*github.PullRequest{
+				pr(2, "chore: Bump the dependencies", "auto-upgrade/v1.17.0", "closed", true),
+			},
+			want: 2,
+		},
+		{
+			name: "the newest one",
+			prs: []*github.PullRequest{
+				pr(3, "chore: Upgrade framework to v1.17.0 (auto)", "upgrade/v1.17.0-2", "open", false),
+				pr(2, "chore: Upgrade framework to v1.17.0 (auto)", "upgrade/v1.17.0", "closed", true),
+			},
+			want: 3,
+		},
+		{
+			name: "skip the PRs closed without being merged",
+			prs: []*github.PullRequest{
+				pr(3, "chore: Upgrade framework to v1.17.0", "upgrade/v1.17.0-2", "closed", false),
+				pr(2, "chore: Upgrade framework to v1.17.0", "upgrade/v1.17.0", "closed", true),
+			},
+			want: 2,
+		},
+		{
+			name: "skip the other versions",
+			prs: []*github.PullRequest{
+				pr(4, "chore: Upgrade framework to v1.17.0-rc.1", "upgrade/v1.17.0-rc.1", "closed", true),
+				pr(3, "chore: Upgrade framework to v1.17.01", "upgrade/v1.17.01", "open", false),
+				pr(2, "fix: Mention v1.17.0 in the docs", "fix/docs", "open", false),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := findAutoUpgradePR(tt.prs, "v1.17.0")
+			if tt.want == 0 {
+				assert.Nil(t, found)
+			} else {
+				assert.Equal(t, tt.want, found.GetNumber())
+			}
+		})
+	}
+}
+
+func TestGoModMismatches(t *testing.T) {
+	releasedTags := map[string]string{
+		"github.com/goravel/framework": "v1.17.0",
+		"github.com/goravel/gin":       "v1.17.0",
+		"github.com/goravel/redis":     "v1.17.0",
+	}
+
+	tests := []struct {
+		name  string
+		goMod string
+		want  []string
+	}{
+		{
+			name:  "up to date",
+			goMod: "module goravel\n\nrequire (\n\tgithub.com/goravel/framework v1.17.0\n\tgithub.com/goravel/gin v1.17.0\n)\n",
+		},
+		{
+			name:  "not upgraded",
+			goMod: "module goravel\n\nrequire (\n\tgithub.com/goravel/framework v1.16.2\n\tgithub.com/goravel/gin v1.16.0\n\tgithub.com/goravel/redis v1.17.0\n)\n",
+			want: []string{
+				"github.com/goravel/framework v1.16.2, want v1.17.0",
+				"github.com/goravel/gin v1.16.0, want v1.17.0",
+			},
+		},
+		{
+			name:  "framework not required",
+			goMod: "module goravel\n\nrequire github.com/goravel/gin v1.17.0\n",
+			want:  []string{"github.com/goravel/framework is not required, want v1.17.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches, err := goModMismatches("goravel", []byte(tt.goMod), "github.com/goravel/framework", releasedTags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mismatches)
+		})
+	}
+
+	_, err := goModMismatches("goravel", []byte("require ("), "github.com/goravel/framework", releasedTags)
+	assert.ErrorContains(t, err, "failed to parse go.mod of goravel")
+}
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type ChangelogGroup string
+
+const (
+	ChangelogBreaking ChangelogGroup = "Breaking Changes"
+	ChangelogFeat     ChangelogGroup = "Features"
+	ChangelogFix      ChangelogGroup = "Fixes"
+	ChangelogPerf     ChangelogGroup = "Performance"
+	ChangelogChore    ChangelogGroup = "Chores"
+	ChangelogDeps     ChangelogGroup = "Dependencies"
+	// ChangelogOther the titles not following conventional commits
+	ChangelogOther ChangelogGroup = "Other Changes"
+)
+
+// changelogGroups is the order of the groups in the changelog and the release notes.
+var changelogGroups = []ChangelogGroup{ChangelogBreaking, ChangelogFeat, ChangelogFix, ChangelogPerf, ChangelogChore, ChangelogDeps, ChangelogOther}
+
+var (
+	// changelogEntryRegexp matches a PR line of the GitHub generated notes, e.g.
+	// * feat: Add xxx by @hwbrzzl in https://github.com/goravel/framework/pull/123
+	changelogEntryRegexp = regexp.MustCompile(`^[*-]\s+(.+?)(?:\s+by\s+@(\S+))?\s+in\s+(https://\S+/pull/(\d+))\s*$`)
+	// conventionalCommitRegexp matches a conventional commit title, e.g. feat(http)!: Add xxx
+	conventionalCommitRegexp = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+)
+
+// ReleaseChangelog aggregates the generated release notes of all repos into one announcement.
+type ReleaseChangelog struct {
+	// The released tag
+	Tag string
+	// The entries, in order of adding
+	Entries []*ChangelogEntry
+}
+
+type ChangelogEntry struct {
+	// The repo the PR belongs to, e.g. goravel/framework
+	Repo string
+	// The group of the PR, according to the conventional commit type
+	Group ChangelogGroup
+	// The conventional commit scope, e.g. http of fix(http): xxx
+	Scope string
+	// The PR title without the conventional commit prefix
+	Title string
+	// The PR author
+	Author string
+	// The PR number
+	Number string
+	// The PR link
+	URL string
+}
+
+func NewReleaseChangelog(tag string) *ReleaseChangelog {
+	return &ReleaseChangelog{Tag: tag}
+}
+
+// Add parses the PR lines of the generated notes of the repo, the other lines are ignored. A PR is added once,
+// and so is the same title in the same repo, given a fix is usually cherry-picked to the version branch.
+func (r *ReleaseChangelog) Add(repo, notes string) {
+	for _, line := range strings.Split(notes, "\n") {
+		matches := changelogEntryRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		entry := newChangelogEntry(repo, matches[1])
+		entry.Author = matches[2]
+		entry.URL = matches[3]
+		entry.Number = matches[4]
+
+		if slices.ContainsFunc(r.Entries, func(existing *ChangelogEntry) bool {
+			return existing.URL == entry.URL || (existing.Repo == entry.Repo && existing.Group == entry.Group && existing.Scope == entry.Scope && existing.Title == entry.Title)
+		}) {
+			continue
+		}
+
+		r.Entries = append(r.Entries, entry)
+	}
+}
+
+// Markdown renders the changelog for the goravel.dev release page, the entries are grouped by type, then by repo.
+func (r *ReleaseChangelog) Markdown() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Goravel %s\n", r.Tag))
+
+	for _, group := range changelogGroups {
+		var (
+			repos         []string
+			repoToEntries = make(map[string][]*ChangelogEntry)
+		)
+		for _, entry := range r.Entries {
+			if entry.Group != group {
+				continue
+			}
+			if _, exist := repoToEntries[entry.Repo]; !exist {
+				repos = append(repos, entry.Repo)
+			}
+
+			repoToEntries[entry.Repo] = append(repoToEntries[entry.Repo], entry)
+		}
+		if len(repos) == 0 {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("\n## %s\n", group))
+		for _, repo := range repos {
+			builder.WriteString(fmt.Sprintf("\n### %s\n\n", repo))
+			for _, entry := range repoToEntries[repo] {
+				builder.WriteString(entry.Markdown() + "\n")
+			}
+		}
+	}
+
+	if len(r.Entries) == 0 {
+		builder.WriteString("\nNo changes.\n")
+	}
+
+	return builder.String()
+}
+
+func (r *ReleaseChangelog) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create changelog directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(r.Markdown()), 0644); err != nil {
+		return fmt.Errorf("failed to save changelog %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func newChangelogEntry(repo, title string) *ChangelogEntry {
+	entry := &ChangelogEntry{Repo: repo, Group: ChangelogOther, Title: title}
+
+	matches := conventionalCommitRegexp.FindStringSubmatch(title)
+	if matches == nil {
+		return entry
+	}
+
+	entry.Group = changelogGroup(matches[1], matches[2], matches[3] == "!" || strings.Contains(title, "BREAKING CHANGE"))
+	entry.Scope = matches[2]
+	entry.Title = matches[4]
+
+	return entry
+}
+
+// changelogGroup returns the group of a conventional commit, the types other than feat, fix and perf are chores,
+// except the dependency updates, e.g. chore(deps): xxx.
+func changelogGroup(typ, scope string, breaking bool) ChangelogGroup {
+	switch {
+	case breaking:
+		return ChangelogBreaking
+	case typ == "feat":
+		return ChangelogFeat
+	case typ == "fix":
+		return ChangelogFix
+	case typ == "perf":
+		return ChangelogPerf
+	case typ == "deps" || scope == "deps":
+		return ChangelogDeps
+	default:
+		return ChangelogChore
+	}
+}
+
+func (r *ChangelogEntry) Markdown() string {
+	title := r.Title
+	if r.Scope != "" {
+		title = fmt.Sprintf("**%s**: %s", r.Scope, title)
+	}
+
+	line := fmt.Sprintf("- %s ([#%s](%s))", title, r.Number, r.URL)
+	if r.Author != "" {
+		line += fmt.Sprintf(" by @%s", r.Author)
+	}
+
+	return line
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseChangelog(t *testing.T) {
+	changelog := NewReleaseChangelog("v1.17.0")
+	changelog.Add("goravel/framework", `## What's Changed
+* feat(http): Add rate limiter by @hwbrzzl in https://github.com/goravel/framework/pull/10
+* fix: Fix the session driver by @alice in https://github.com/goravel/framework/pull/11
+* feat!: Remove the deprecated methods by @hwbrzzl in https://github.com/goravel/framework/pull/12
+* perf(orm): Cache the model fields by @bob in https://github.com/goravel/framework/pull/13
+* chore: Upgrade dependencies by @renovate in https://github.com/goravel/framework/pull/14
+* fix: Fix the session driver by @alice in https://github.com/goravel/framework/pull/15
+
+## New Contributors
+* @bob made their first contribution in https://github.com/goravel/framework/pull/13
+
+**Full Changelog**: https://github.com/goravel/framework/compare/v1.16.0...v1.17.0`)
+	changelog.Add("goravel/gin", `## What's Changed
+* fix: Fix the session driver by @alice in https://github.com/goravel/gin/pull/3
+* feat(http): Add rate limiter by @hwbrzzl in https://github.com/goravel/framework/pull/10`)
+
+	assert.Len(t, changelog.Entries, 6)
+	assert.Equal(t, &ChangelogEntry{
+		Repo:   "goravel/framework",
+		Group:  ChangelogFeat,
+		Scope:  "http",
+		Title:  "Add rate limiter",
+		Author: "hwbrzzl",
+		Number: "10",
+		URL:    "https://github.com/goravel/framework/pull/10",
+	}, changelog.Entries[0])
+
+	assert.Equal(t, `# Goravel v1.17.0
+
+## Breaking Changes
+
+### goravel/framework
+
+- Remove the deprecated methods ([#12](https://github.com/goravel/framework/pull/12)) by @hwbrzzl
+
+## Features
+
+### goravel/framework
+
+- **http**: Add rate limiter ([#10](https://github.com/goravel/framework/pull/10)) by @hwbrzzl
+
+## Fixes
+
+### goravel/framework
+
+- Fix the session driver ([#11](https://github.com/goravel/framework/pull/11)) by @alice
+
+### goravel/gin
+
+- Fix the session driver ([#3](https://github.com/goravel/gin/pull/3)) by @alice
+
+## Performance
+
+### goravel/framework
+
+- **orm**: Cache the model fields ([#13](https://github.com/goravel/framework/pull/13)) by @bob
+
+## Chores
+
+### goravel/framework
+
+- Upgrade dependencies ([#14](https://github.com/goravel/framework/pull/14)) by @renovate
+`, changelog.Markdown())
+
+	path := filepath.Join(t.TempDir(), "changelog.md")
+	require.NoError(t, changelog.Save(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, changelog.Markdown(), string(content))
+
+	assert.Equal(t, "# Goravel v1.17.1\n\nNo changes.\n", NewReleaseChangelog("v1.17.1").Markdown())
+}
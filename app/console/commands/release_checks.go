@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+)
+
+type CheckState string
+
+const (
+	CheckSuccess CheckState = "success"
+	CheckPending CheckState = "pending"
+	CheckFailure CheckState = "failure"
+)
+
+// CommitCheck is a commit status or a check run at the commit to release.
+type CommitCheck struct {
+	// The status context or the check run name, e.g. test (ubuntu-latest)
+	Name string
+	// The state of the check
+	State CheckState
+	// The link to the details, it's empty if the required check hasn't been reported
+	URL string
+}
+
+// newCommitChecks merges the commit statuses and the check runs at the commit, sorted by name. If the branch
+// requires checks, only the required ones are gated on and the ones not reported yet are pending.
+func newCommitChecks(status *github.CombinedStatus, checkRuns []*github.CheckRun, required []string) []*CommitCheck {
+	var checks []*CommitCheck
+	if status != nil {
+		for _, repoStatus := range status.Statuses {
+			checks = append(checks, &CommitCheck{
+				Name:  repoStatus.GetContext(),
+				State: statusCheckState(repoStatus.GetState()),
+				URL:   repoStatus.GetTargetURL(),
+			})
+		}
+	}
+	for _, checkRun := range checkRuns {
+		checks = append(checks, &CommitCheck{
+			Name:  checkRun.GetName(),
+			State: checkRunState(checkRun.GetStatus(), checkRun.GetConclusion()),
+			URL:   checkRun.GetHTMLURL(),
+		})
+	}
+	slices.SortStableFunc(checks, func(a, b *CommitCheck) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if len(required) == 0 {
+		return checks
+	}
+
+	var requiredChecks []*CommitCheck
+	for _, name := range required {
+		index := slices.IndexFunc(checks, func(check *CommitCheck) bool {
+			return check.Name == name
+		})
+		if index == -1 {
+			requiredChecks = append(requiredChecks, &CommitCheck{Name: name, State: CheckPending})
+			continue
+		}
+
+		requiredChecks = append(requiredChecks, checks[index])
+	}
+
+	return requiredChecks
+}
+
+// filterChecks returns the names of the failing and the pending checks.
+func filterChecks(checks []*CommitCheck) ([]string, []string) {
+	var failing, pending []string
+	for _, check := range checks {
+		switch check.State {
+		case CheckFailure:
+			failing = append(failing, check.Name)
+		case CheckPending:
+			pending = append(pending, check.Name)
+		}
+	}
+
+	return failing, pending
+}
+
+// statusCheckState maps the state of a commit status: success, pending, failure or error.
+func statusCheckState(state string) CheckState {
+	switch state {
+	case "success":
+		return CheckSuccess
+	case "pending":
+		return CheckPending
+	default:
+		return CheckFailure
+	}
+}
+
+// checkRunState maps a check run, it's pending until completed, and a neutral or skipped conclusion passes.
+func checkRunState(status, conclusion string) CheckState {
+	if status != "completed" {
+		return CheckPending
+	}
+
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CheckSuccess
+	default:
+		return CheckFailure
+	}
+}
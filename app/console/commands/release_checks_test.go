@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/goravel/framework/support/convert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommitChecks(t *testing.T) {
+	status := &github.CombinedStatus{Statuses: []*github.RepoStatus{
+		{Context: convert.Pointer("codecov/patch"), State: convert.Pointer("success"), TargetURL: convert.Pointer("https://codecov.io/1")},
+		{Context: convert.Pointer("codecov/project"), State: convert.Pointer("pending")},
+		{Context: convert.Pointer("license"), State: convert.Pointer("error")},
+	}}
+	checkRun := func(name, status, conclusion string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       convert.Pointer(name),
+			Status:     convert.Pointer(status),
+			Conclusion: convert.Pointer(conclusion),
+		}
+	}
+	checkRuns := []*github.CheckRun{
+		checkRun("test", "completed", "success"),
+		checkRun("lint", "completed", "neutral"),
+		checkRun("benchmark", "completed", "skipped"),
+		checkRun("windows", "queued", ""),
+		checkRun("codeql", "completed", "action_required"),
+	}
+
+	tests := []struct {
+		name      string
+		status    *github.CombinedStatus
+		checkRuns []*github.CheckRun
+		required  []string
+		want      []*CommitCheck
+	}{
+		{
+			name: "no checks",
+		},
+		{
+			name:      "all checks",
+			status:    status,
+			checkRuns: checkRuns,
+			want: []*CommitCheck{
+				{Name: "benchmark", State: CheckSuccess},
+				{Name: "codecov/patch", State: CheckSuccess, URL: "https://codecov.io/1"},
+				{Name: "codecov/project", State: CheckPending},
+				{Name: "codeql", State: CheckFailure},
+				{Name: "license", State: CheckFailure},
+				{Name: "lint", State: CheckSuccess},
+				{Name: "test", State: CheckSuccess},
+				{Name: "windows", State: CheckPending},
+			},
+		},
+		{
+			name:      "required checks",
+			status:    status,
+			checkRuns: checkRuns,
+			required:  []string{"test", "codecov/patch", "macos"},
+			want: []*CommitCheck{
+				{Name: "test", State: CheckSuccess},
+				{Name: "codecov/patch", State: CheckSuccess, URL: "https://codecov.io/1"},
+				{Name: "macos", State: CheckPending},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newCommitChecks(tt.status, tt.checkRuns, tt.required))
+		})
+	}
+}
+
+func TestFilterChecks(t *testing.T) {
+	failing, pending := filterChecks([]*CommitCheck{
+		{Name: "codeql", State: CheckFailure},
+		{Name: "lint", State: CheckSuccess},
+		{Name: "test", State: CheckPending},
+		{Name: "windows", State: CheckFailure},
+	})
+
+	assert.Equal(t, []string{"codeql", "windows"}, failing)
+	assert.Equal(t, []string{"test"}, pending)
+}
@@ -0,0 +1,679 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/goravel/framework/contracts/console"
+	contractsprocess "github.com/goravel/framework/contracts/process"
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	mocksclient "github.com/goravel/framework/mocks/http/client"
+	"github.com/goravel/framework/process"
+	testingmock "github.com/goravel/framework/testing/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/facades"
+	"goravel/app/services"
+	"goravel/app/testing/fakegithub"
+	"goravel/app/testing/gitfixture"
+)
+
+// The e2e tests run the commands against the fake GitHub server, the manifest doesn't push any branch
+// or bump any Version constant, so the framework and gin are cloned from the bare repos to check their API
+// and gin to be upgraded.
+func TestReleaseE2E(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		real    bool
+		setup   func(fixtures *gitfixture.Fixtures)
+		serve   func(server *fakegithub.Server)
+		run     func(release *Release) error
+		wantErr string
+		assert  func(t *testing.T, server *fakegithub.Server)
+	}{
+		{
+			name: "Major",
+			tag:  "v1.17.0",
+			real: true,
+			setup: func(fixtures *gitfixture.Fixtures) {
+				// The incompatible changes are only listed for a major release.
+				fixtures.AddCommit(owner, "framework", "v1.17.x", "feat: Remove Boot", map[string]string{
+					"foundation/application.go": "package foundation\n\nfunc Start() {}\n",
+				})
+			},
+			run: func(release *Release) error {
+				return release.Major()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "v1.17.x", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.17.0", "v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+					assert.Equal(t, "v1.17.x", repo.Releases[0].GetTargetCommitish())
+					assert.False(t, repo.Releases[0].GetPrerelease())
+					assert.Contains(t, repo.Releases[0].GetBody(), "compare/v1.16.1...v1.17.0")
+				}
+				gin := server.Repo(owner, "gin")
+				require.Len(t, gin.Pulls, 1)
+				assert.True(t, gin.Pulls[0].GetMerged())
+				assert.Equal(t, []string{"v1.17.0", "v1.16.1", "v1.16.0"}, releaseTags(gin.Releases))
+				assert.Equal(t, "v1.17.x", gin.Releases[0].GetTargetCommitish())
+				assert.Equal(t, []string{
+					"create_release framework",
+					"set_default_branch framework",
+					"push_branch gin",
+					"create_pull_request gin",
+					"merge_pull_request gin",
+					"create_release gin",
+					"create_release goravel",
+					"set_default_branch goravel",
+				}, auditActions(t, "major", "v1.17.0"))
+			},
+		},
+		{
+			name: "Major in preview mode",
+			tag:  "v1.17.0",
+			run: func(release *Release) error {
+				return release.Major()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "gin", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "master", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+				}
+				assert.Empty(t, server.Repo(owner, "gin").Pulls)
+			},
+		},
+		{
+			// The module proxy doesn't serve v1.18.0, so the upgrade of gin can't go get it.
+			name: "Major in preview mode upgrades the packages against the release branches",
+			tag:  "v1.18.0",
+			run: func(release *Release) error {
+				return release.Major()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				assert.Empty(t, server.Repo(owner, "gin").Pulls)
+
+				content, err := os.ReadFile(facades.App().StoragePath("release", "major-v1.18.0-plan.json"))
+				require.NoError(t, err)
+
+				var plan struct {
+					Actions []struct {
+						Type    services.PlanActionType
+						Repo    string
+						Payload json.RawMessage
+					}
+				}
+				require.NoError(t, json.Unmarshal(content, &plan))
+
+				var pushes []*services.PushBranchPayload
+				for _, action := range plan.Actions {
+					if action.Type == services.PlanActionPushBranch && action.Repo == "gin" {
+						var payload services.PushBranchPayload
+						require.NoError(t, json.Unmarshal(action.Payload, &payload))
+						pushes = append(pushes, &payload)
+					}
+				}
+				require.Len(t, pushes, 1)
+				assert.Equal(t, "auto-upgrade/v1.18.0", pushes[0].Branch)
+				assert.Equal(t, []string{"go get github.com/goravel/framework@v1.18.0"}, pushes[0].Commands)
+				assert.Contains(t, pushes[0].DiffStat, "go.mod")
+			},
+		},
+		{
+			name: "Patch",
+			tag:  "v1.16.2",
+			real: true,
+			setup: func(fixtures *gitfixture.Fixtures) {
+				fixtures.AddCommit(owner, "framework", "v1.16.x", "feat: Add Shutdown", map[string]string{
+					"foundation/application.go": "package foundation\n\nfunc Boot() {}\n\nfunc Shutdown() {}\n",
+				})
+			},
+			run: func(release *Release) error {
+				return release.Patch()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "master", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.16.2", "v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+					assert.Equal(t, "v1.16.x", repo.Releases[0].GetTargetCommitish())
+					assert.Contains(t, repo.Releases[0].GetBody(), "compare/v1.16.1...v1.16.2")
+				}
+			},
+		},
+		{
+			name: "Patch with incompatible API changes",
+			tag:  "v1.16.2",
+			real: true,
+			setup: func(fixtures *gitfixture.Fixtures) {
+				fixtures.AddCommit(owner, "framework", "v1.16.x", "fix: Boot with options", map[string]string{
+					"foundation/application.go": "package foundation\n\nfunc Boot(options ...string) {}\n",
+				})
+			},
+			run: func(release *Release) error {
+				return release.Patch()
+			},
+			wantErr: "incompatible API changes found in framework, a patch release should not break the API, pass --allow-breaking to release anyway",
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, name).Releases))
+				}
+			},
+		},
+		{
+			name: "Patch blocked by a failing check",
+			tag:  "v1.16.2",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.SetRequiredChecks(owner, "framework", "v1.16.x", "test", "lint")
+				server.AddCheckRun(owner, "framework", "v1.16.x", "test", "completed", "failure")
+				server.AddStatus(owner, "framework", "v1.16.x", "lint", "success")
+			},
+			run: func(release *Release) error {
+				return release.Patch()
+			},
+			wantErr: "the checks of goravel/framework at v1.16.x are failing: test",
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, "framework").Releases))
+			},
+		},
+		{
+			name: "Patch waits for the checks pending after the merge",
+			tag:  "v1.16.2",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.SetRequiredChecks(owner, "framework", "v1.16.x", "test")
+				server.AddPendingCheckRun(owner, "framework", "v1.16.x", "test", 2, "success")
+			},
+			run: func(release *Release) error {
+				release.pollInterval = time.Millisecond
+
+				return release.Patch()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				assert.Equal(t, []string{"v1.16.2", "v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, "framework").Releases))
+			},
+		},
+		{
+			name: "Major with the auto tag",
+			tag:  "auto",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.MergePull(owner, "framework", server.AddPull(owner, "framework", "feat: Add rate limiter", "feat/rate-limiter", "master"))
+			},
+			run: func(release *Release) error {
+				return release.Major()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					assert.Equal(t, []string{"v1.17.0", "v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, name).Releases))
+				}
+			},
+		},
+		{
+			name: "Patch with the auto tag refused by a feature on the version branch",
+			tag:  "auto",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.MergePull(owner, "framework", server.AddPull(owner, "framework", "feat: Add rate limiter", "feat/rate-limiter", "v1.16.x"))
+			},
+			run: func(release *Release) error {
+				return release.Patch()
+			},
+			wantErr: "a patch release is refused, feature or breaking PRs landed on the version branches of framework",
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, "framework").Releases))
+			},
+		},
+		{
+			name: "Rollback of a major release blocked by a failing check",
+			tag:  "v1.17.0",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.SetRequiredChecks(owner, "goravel", "v1.17.x", "test")
+				server.AddCheckRun(owner, "goravel", "v1.17.x", "test", "completed", "failure")
+			},
+			run: func(release *Release) error {
+				// The framework has been released and set to the version branch by then.
+				if err := release.Major(); ExitCode(err) != ExitCodeChecksFailed {
+					return fmt.Errorf("the major release should be blocked by the failing check: %v", err)
+				}
+
+				return release.Rollback()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "master", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+					assert.NotContains(t, repo.Tags, "v1.17.0")
+				}
+				assert.NoFileExists(t, facades.App().StoragePath("release", "major-v1.17.0.json"))
+				assert.Equal(t, []string{
+					"create_release framework",
+					"set_default_branch framework",
+					"push_branch gin",
+					"create_pull_request gin",
+					"merge_pull_request gin",
+					"create_release gin",
+					"delete_release gin",
+					"delete_tag gin",
+					"set_default_branch framework",
+					"delete_release framework",
+					"delete_tag framework",
+				}, auditActions(t, "rollback", "v1.17.0"))
+			},
+		},
+		{
+			name: "Rollback after a fresh run of a failed major release",
+			tag:  "v1.17.0",
+			real: true,
+			serve: func(server *fakegithub.Server) {
+				server.SetRequiredChecks(owner, "goravel", "v1.17.x", "test")
+				server.AddCheckRun(owner, "goravel", "v1.17.x", "test", "completed", "failure")
+			},
+			run: func(release *Release) error {
+				// The second run without --resume finds the framework released and its default branch set already.
+				for range 2 {
+					if err := release.Major(); ExitCode(err) != ExitCodeChecksFailed {
+						return fmt.Errorf("the major release should be blocked by the failing check: %v", err)
+					}
+				}
+
+				return release.Rollback()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "master", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+					assert.NotContains(t, repo.Tags, "v1.17.0")
+				}
+				assert.NoFileExists(t, facades.App().StoragePath("release", "major-v1.17.0.json"))
+			},
+		},
+		{
+			name: "Verify",
+			tag:  "v1.17.0",
+			run: func(release *Release) error {
+				return release.Verify()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(server.Repo(owner, "framework").Releases))
+			},
+		},
+		{
+			name: "Preview",
+			tag:  "v1.16.2",
+			run: func(release *Release) error {
+				return release.Preview()
+			},
+			assert: func(t *testing.T, server *fakegithub.Server) {
+				for _, name := range []string{"framework", "goravel"} {
+					repo := server.Repo(owner, name)
+					assert.Equal(t, "master", repo.DefaultBranch)
+					assert.Equal(t, []string{"v1.16.1", "v1.16.0"}, releaseTags(repo.Releases))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixtures := gitfixture.New(t)
+			fixtures.AddRepo(owner, "framework", map[string]string{
+				"go.mod":                    "module github.com/goravel/framework\n\ngo 1.22\n",
+				"foundation/application.go": "package foundation\n\nfunc Boot() {}\n",
+			}, "v1.16.x", "v1.17.x")
+			fixtures.AddTag(owner, "framework", "v1.16.1", "master")
+			fixtures.AddRepo(owner, "gin", map[string]string{
+				"go.mod": "module github.com/goravel/gin\n\ngo 1.22\n\nrequire github.com/goravel/framework v1.16.1\n",
+				"gin.go": "package gin\n\nimport \"github.com/goravel/framework/foundation\"\n\nfunc Boot() {\n\tfoundation.Boot()\n}\n",
+			}, "v1.16.x", "v1.17.x")
+			fixtures.AddTag(owner, "gin", "v1.16.1", "master")
+			// gin doesn't commit the sum of the framework, it's added once the packages are loaded to check the API.
+			t.Setenv("GOFLAGS", "-modcacherw -mod=mod")
+			// The released framework and gin are served by the module proxy once they're released.
+			for _, version := range []string{"v1.16.1", "v1.16.2", "v1.17.0"} {
+				fixtures.AddModule("github.com/goravel/framework", version, map[string]string{
+					"go.mod":                    "module github.com/goravel/framework\n\ngo 1.22\n",
+					"foundation/application.go": "package foundation\n\nfunc Boot() {}\n",
+				})
+				fixtures.AddModule("github.com/goravel/gin", version, map[string]string{
+					"go.mod": "module github.com/goravel/gin\n\ngo 1.22\n",
+				})
+			}
+			if tt.setup != nil {
+				tt.setup(fixtures)
+			}
+			proxy := fixtures.ServeProxy()
+
+			server := fakegithub.NewServer()
+			defer server.Close()
+
+			for _, name := range []string{"framework", "gin", "goravel"} {
+				server.AddRepo(owner, name, "v1.16.x", "v1.17.x")
+				server.AddRelease(owner, name, "v1.16.0", false)
+				server.AddRelease(owner, name, "v1.16.1", false)
+			}
+			// The auto upgrade PRs created by the workflow of goravel/goravel once the framework is released.
+			for branch, version := range map[string]string{"v1.16.x": "v1.16.2", "v1.17.x": "v1.17.0"} {
+				server.MergePull(owner, "goravel", server.AddPull(owner, "goravel", "chore: Upgrade framework to "+version, "upgrade/"+version, branch))
+			}
+			if tt.serve != nil {
+				tt.serve(server)
+			}
+
+			release := newE2ERelease(t, server, tt.tag, tt.real)
+			release.cloneURLTemplate = fixtures.CloneURL()
+			release.proxyURL = proxy.URL
+			release.sumDBURL = proxy.URL
+			if tt.wantErr != "" {
+				assert.EqualError(t, tt.run(release), tt.wantErr)
+			} else {
+				require.NoError(t, tt.run(release))
+			}
+
+			tt.assert(t, server)
+		})
+	}
+}
+
+func TestChangelogE2E(t *testing.T) {
+	server := fakegithub.NewServer()
+	defer server.Close()
+
+	for _, name := range []string{"framework", "gin", "goravel"} {
+		server.AddRepo(owner, name, "v1.16.x", "v1.17.x")
+		server.AddRelease(owner, name, "v1.16.0", false)
+	}
+	server.MergePull(owner, "framework", server.AddPull(owner, "framework", "feat: Add rate limiter", "feat/rate-limiter", "v1.17.x"))
+	server.MergePull(owner, "framework", server.AddPull(owner, "framework", "fix: Fix the session driver", "fix/session", "v1.17.x"))
+	server.AddPull(owner, "framework", "feat: Add queue batches", "feat/batches", "v1.17.x")
+	server.MergePull(owner, "goravel", server.AddPull(owner, "goravel", "fix: Fix the session driver", "fix/session", "v1.17.x"))
+
+	release := newE2ERelease(t, server, "v1.17.0", false)
+	require.NoError(t, release.Changelog())
+
+	content, err := os.ReadFile(facades.App().StoragePath("release", "changelog-v1.17.0.md"))
+	require.NoError(t, err)
+	assert.Equal(t, `# Goravel v1.17.0
+
+## Features
+
+### goravel/framework
+
+- Add rate limiter ([#1](https://github.com/goravel/framework/pull/1)) by @goravel
+
+## Fixes
+
+### goravel/framework
+
+- Fix the session driver ([#2](https://github.com/goravel/framework/pull/2)) by @goravel
+
+### goravel/goravel
+
+- Fix the session driver ([#1](https://github.com/goravel/goravel/pull/1)) by @goravel
+`, string(content))
+}
+
+// The smoke e2e test builds an installer from the bare repos, whose new command writes a project without
+// dependencies, so the project can be built, tested and run without network.
+func TestSmokeE2E(t *testing.T) {
+	installer := func(test string) string {
+		return fmt.Sprintf(`package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if len(os.Args) != 3 || os.Args[1] != "new" {
+		os.Exit(1)
+	}
+
+	files := map[string]string{
+		"go.mod":       "module smoke\n\ngo 1.22\n",
+		"main.go":      "package main\n\nimport \"os\"\n\nfunc main() {\n\tif len(os.Args) > 1 && os.Args[1] == \"artisan\" {\n\t\tprintln(\"Available commands\")\n\t}\n}\n",
+		"main_test.go": %q,
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(os.Args[2], 0755); err != nil {
+			panic(err)
+		}
+		if err := os.WriteFile(filepath.Join(os.Args[2], name), []byte(content), 0644); err != nil {
+			panic(err)
+		}
+	}
+}
+`, test)
+	}
+
+	fixtures := gitfixture.New(t)
+	fixtures.AddRepo(owner, "installer", map[string]string{
+		"go.mod":  "module github.com/goravel/installer\n\ngo 1.22\n",
+		"main.go": installer("package main\n\nimport \"testing\"\n\nfunc TestSmoke(t *testing.T) {}\n"),
+	})
+	fixtures.AddTag(owner, "installer", "v1.17.0", "master")
+	fixtures.AddCommit(owner, "installer", "master", "fix: Break the test", map[string]string{
+		"main.go": installer("package main\n\nimport \"testing\"\n\nfunc TestSmoke(t *testing.T) {\n\tt.Fatal(\"broken\")\n}\n"),
+	})
+	fixtures.AddTag(owner, "installer", "v1.17.1", "master")
+
+	server := fakegithub.NewServer()
+	defer server.Close()
+
+	for _, tt := range []struct {
+		tag     string
+		wantErr string
+	}{
+		{tag: "v1.17.0"},
+		{tag: "v1.17.1", wantErr: "smoke test of v1.17.1 failed at go test: exit status 1"},
+	} {
+		t.Run(tt.tag, func(t *testing.T) {
+			release := newE2ERelease(t, server, tt.tag, false)
+			release.manifest = newTestManifest(
+				&Repo{Name: "framework", Role: RoleFramework},
+				&Repo{Name: "installer", Role: RolePackage, Dependencies: []string{"framework"}},
+			)
+			release.cloneURLTemplate = fixtures.CloneURL()
+
+			err := release.Smoke()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, ExitCodeChecksFailed, ExitCode(err))
+			}
+		})
+	}
+}
+
+// The upgrade e2e test runs the real git and go commands against the bare repos and the local module proxy,
+// then asserts on the commits pushed to the bare repos.
+func TestUpgradeE2E(t *testing.T) {
+	fixtures := gitfixture.New(t)
+	for _, version := range []string{"v1.16.0", "v1.17.0"} {
+		fixtures.AddModule("github.com/goravel/framework", version, map[string]string{
+			"go.mod":              "module github.com/goravel/framework\n\ngo 1.22\n",
+			"support/constant.go": fmt.Sprintf("package support\n\nconst Version = %q\n", version),
+		})
+	}
+	fixtures.AddRepo(owner, "gin", map[string]string{
+		"go.mod": "module github.com/goravel/gin\n\ngo 1.22\n\nrequire github.com/goravel/framework v1.16.0\n",
+		"gin.go": "package gin\n\nimport \"github.com/goravel/framework/support\"\n\nvar Version = support.Version\n",
+	})
+
+	server := fakegithub.NewServer()
+	defer server.Close()
+	server.AddRepo(owner, "gin")
+
+	release := newE2ERelease(t, server, "v1.17.0", true)
+	release.manifest = newTestManifest(
+		&Repo{Name: "framework", Role: RoleFramework},
+		&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework"}, Branch: true},
+	)
+	release.cloneURLTemplate = fixtures.CloneURL()
+	release.setup()
+
+	t.Run("createUpgradePRs", func(t *testing.T) {
+		upgrade := release.newUpgradeInformation("gin", "master", "v1.17.0", release.getUpgradeCommands([]string{"framework"}, "v1.17.0"), map[string]string{"framework": "master"})
+
+		repoToPR, err := release.createUpgradePRs([]*UpgradeInformation{upgrade})
+		require.NoError(t, err)
+		require.NotNil(t, repoToPR["gin"])
+		assert.Equal(t, 1, repoToPR["gin"].GetNumber())
+
+		assert.Equal(t, []string{"chore: Upgrade framework to v1.17.0 (auto)", "Initial commit"}, fixtures.Commits(owner, "gin", "auto-upgrade/v1.17.0"))
+		assert.Contains(t, fixtures.File(owner, "gin", "auto-upgrade/v1.17.0", "go.mod"), "require github.com/goravel/framework v1.17.0")
+		assert.Contains(t, fixtures.File(owner, "gin", "auto-upgrade/v1.17.0", "go.sum"), "github.com/goravel/framework v1.17.0")
+		assert.Equal(t, []string{"Initial commit"}, fixtures.Commits(owner, "gin", "master"))
+
+		pulls := server.Repo(owner, "gin").Pulls
+		require.Len(t, pulls, 1)
+		assert.Equal(t, "auto-upgrade/v1.17.0", pulls[0].GetHead().GetRef())
+		assert.Equal(t, "master", pulls[0].GetBase().GetRef())
+
+		entries := auditEntries(t)
+		require.Len(t, entries, 2)
+		assert.Equal(t, services.PlanActionPushBranch, entries[0].Action)
+		assert.Equal(t, fmt.Sprintf(fixtures.CloneURL(), owner, "gin"), entries[0].URL)
+		assert.Equal(t, services.PlanActionCreatePullRequest, entries[1].Action)
+		assert.Equal(t, int64(1), entries[1].ID)
+	})
+
+	t.Run("checkPRsMergeStatus with auto merge", func(t *testing.T) {
+		server.SetRequiredChecks(owner, "gin", "master", "test")
+		server.AddCheckRun(owner, "gin", "auto-upgrade/v1.17.0", "test", "completed", "success")
+		release.autoMerge = true
+		release.pollInterval = time.Millisecond
+
+		require.NoError(t, release.checkPRsMergeStatus(map[string]*github.PullRequest{
+			"gin": server.Repo(owner, "gin").Pulls[0],
+		}))
+
+		repo := server.Repo(owner, "gin")
+		assert.True(t, repo.Pulls[0].GetMerged())
+		assert.Equal(t, []string{"squash"}, repo.MergeMethods)
+	})
+
+	t.Run("pushBranch", func(t *testing.T) {
+		require.NoError(t, release.pushBranch("gin", "v1.17.x"))
+
+		assert.Contains(t, fixtures.Branches(owner, "gin"), "v1.17.x")
+		assert.Equal(t, []string{"Initial commit"}, fixtures.Commits(owner, "gin", "v1.17.x"))
+	})
+}
+
+// newE2ERelease creates the release of the framework and goravel/goravel, every question is confirmed
+// and the GitHub API requests are sent to the fake server.
+func newE2ERelease(t *testing.T, server *fakegithub.Server, tag string, real bool) *Release {
+	storage := t.TempDir()
+	mockFactory := testingmock.Factory()
+
+	mockConfig := mockFactory.Config()
+	mockConfig.EXPECT().GetString("GITHUB_TOKEN").Return("token").Maybe()
+	mockConfig.EXPECT().GetString("release.github_url").Return(server.URL).Maybe()
+
+	mockApp := mockFactory.App()
+	mockApp.EXPECT().StoragePath(mock.Anything, mock.Anything).RunAndReturn(func(path ...string) string {
+		return filepath.Join(append([]string{storage}, path...)...)
+	}).Maybe()
+	mockApp.EXPECT().MakeProcess().RunAndReturn(func() contractsprocess.Process {
+		return process.New()
+	}).Maybe()
+
+	mockHttp := mockFactory.Http()
+	for _, name := range []string{"framework", "gin", "goravel"} {
+		mockResponse := mocksclient.NewResponse(t)
+		mockResponse.EXPECT().Failed().Return(false).Maybe()
+		mockResponse.EXPECT().Body().Return(fmt.Sprintf("module github.com/goravel/%s\n\nrequire github.com/goravel/framework v1.16.1\n", name), nil).Maybe()
+		mockHttp.EXPECT().Get(fmt.Sprintf("https://raw.githubusercontent.com/goravel/%s/refs/heads/master/go.mod", name)).Return(mockResponse, nil).Maybe()
+	}
+	// goravel/goravel requires the released framework once its auto upgrade PR is merged.
+	for branch, version := range map[string]string{"v1.16.x": "v1.16.2", "v1.17.x": "v1.17.0"} {
+		mockResponse := mocksclient.NewResponse(t)
+		mockResponse.EXPECT().Failed().Return(false).Maybe()
+		mockResponse.EXPECT().Body().Return(fmt.Sprintf("module goravel\n\nrequire github.com/goravel/framework %s\n", version), nil).Maybe()
+		mockHttp.EXPECT().Get(fmt.Sprintf("https://raw.githubusercontent.com/goravel/goravel/refs/heads/%s/go.mod", branch)).Return(mockResponse, nil).Maybe()
+	}
+
+	mockContext := mocksconsole.NewContext(t)
+	mockContext.EXPECT().ArgumentString("tag").Return(tag).Maybe()
+	mockContext.EXPECT().OptionBool("real").Return(real).Maybe()
+	mockContext.EXPECT().OptionBool("auto-merge").Return(true).Maybe()
+	mockContext.EXPECT().OptionBool(mock.Anything).Return(false).Maybe()
+	mockContext.EXPECT().OptionInt("concurrency").Return(1).Maybe()
+	mockContext.EXPECT().OptionInt("merge-timeout").Return(1).Maybe()
+	// The default of the flag.
+	mockContext.EXPECT().OptionInt("wait-checks").Return(30).Maybe()
+	mockContext.EXPECT().OptionInt(mock.Anything).Return(0).Maybe()
+	mockContext.EXPECT().Option(mock.Anything).Return("").Maybe()
+	mockContext.EXPECT().Confirm(mock.Anything).Return(true).Maybe()
+	mockContext.EXPECT().Spinner(mock.Anything, mock.AnythingOfType("console.SpinnerOption")).
+		RunAndReturn(func(_ string, option console.SpinnerOption) error {
+			return option.Action()
+		}).Maybe()
+	mockContext.EXPECT().TwoColumnDetail(mock.Anything, mock.Anything).Return().Maybe()
+	mockContext.EXPECT().TwoColumnDetail(mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockContext.EXPECT().NewLine().Return().Maybe()
+
+	return &Release{
+		ctx: mockContext,
+		manifest: newTestManifest(
+			&Repo{Name: "framework", Role: RoleFramework, DefaultBranch: true},
+			&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework"}},
+			&Repo{Name: "goravel", Role: RoleApp, Dependencies: []string{"framework"}, DefaultBranch: true, AutoUpgrade: true},
+		),
+	}
+}
+
+func auditEntries(t *testing.T) []*services.AuditEntry {
+	content, err := os.ReadFile(facades.App().StoragePath("release", "audit.jsonl"))
+	require.NoError(t, err)
+
+	var entries []*services.AuditEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var entry services.AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, &entry)
+	}
+
+	return entries
+}
+
+// auditActions returns the actions in the audit log in order, e.g. create_release framework, the entries of the
+// command are checked to be of the tag and to succeed.
+func auditActions(t *testing.T, command, tag string) []string {
+	var actions []string
+	for _, entry := range auditEntries(t) {
+		if entry.Command == command {
+			assert.Equal(t, tag, entry.Tag)
+			assert.Empty(t, entry.Error)
+		}
+		actions = append(actions, fmt.Sprintf("%s %s", entry.Action, entry.Repo))
+	}
+
+	return actions
+}
+
+func releaseTags(releases []*github.RepositoryRelease) []string {
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.GetTagName())
+	}
+
+	return tags
+}
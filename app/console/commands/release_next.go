@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+
+	"goravel/app/services"
+)
+
+type VersionBump int
+
+const (
+	BumpPatch VersionBump = iota
+	BumpMinor
+	// BumpMajor a breaking change, e.g. v1.16.2 to v2.0.0
+	BumpMajor
+)
+
+func (r VersionBump) String() string {
+	switch r {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// RepoVersionBump is the bump required by the PRs merged into a repo since its latest release.
+type RepoVersionBump struct {
+	// The repo name
+	Repo string
+	// The latest final release, empty if the repo has never been released
+	LatestTag string
+	// The branch the PRs are merged into
+	Branch string
+	// The required bump
+	Bump VersionBump
+	// The PRs requiring a minor or major bump, e.g. feat: Add xxx (#123)
+	Reasons []string
+	// The number of PRs merged since the latest release
+	Merged int
+}
+
+// newRepoVersionBump resolves the bump from the commits since the latest release: a breaking change requires a major
+// bump, a feature requires a minor bump, anything else a patch bump. The PRs of this tool are not counted.
+func newRepoVersionBump(repo, latestTag, branch string, commits []*github.RepositoryCommit) *RepoVersionBump {
+	versionBump := &RepoVersionBump{Repo: repo, LatestTag: latestTag, Branch: branch}
+
+	for _, commit := range commits {
+		message := commit.GetCommit().GetMessage()
+		title, number := releaseNotesPR(message)
+		if autoPRTitleRegexp.MatchString(title) {
+			continue
+		}
+		versionBump.Merged++
+
+		bump := commitVersionBump(message)
+		if bump == BumpPatch {
+			continue
+		}
+
+		reason := title
+		if number != "" {
+			reason += fmt.Sprintf(" (#%s)", number)
+		}
+		versionBump.Reasons = append(versionBump.Reasons, reason)
+		versionBump.Bump = max(versionBump.Bump, bump)
+	}
+
+	return versionBump
+}
+
+// commitVersionBump returns the bump required by the commit, following conventional commits, e.g. feat!: xxx or
+// a BREAKING CHANGE footer is a breaking change.
+func commitVersionBump(message string) VersionBump {
+	title, _ := releaseNotesPR(message)
+
+	switch group := newChangelogEntry("", title).Group; {
+	case group == ChangelogBreaking || strings.Contains(message, "BREAKING CHANGE"):
+		return BumpMajor
+	case group == ChangelogFeat:
+		return BumpMinor
+	default:
+		return BumpPatch
+	}
+}
+
+// bumpVersion returns the next version of the bump, e.g. the minor bump of v1.16.2 is v1.17.0.
+func bumpVersion(version *services.Version, bump VersionBump) *services.Version {
+	switch bump {
+	case BumpMajor:
+		return &services.Version{Major: version.Major + 1}
+	case BumpMinor:
+		return &services.Version{Major: version.Major, Minor: version.Minor + 1}
+	default:
+		return &services.Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1}
+	}
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/services"
+)
+
+func TestNewRepoVersionBump(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []*github.RepositoryCommit
+		want    *RepoVersionBump
+	}{
+		{
+			name: "no PRs",
+			want: &RepoVersionBump{Repo: "framework", LatestTag: "v1.16.1", Branch: "master"},
+		},
+		{
+			name: "fixes only",
+			commits: []*github.RepositoryCommit{
+				repositoryCommit("fix: Fix the session driver (#2)", "hwbrzzl"),
+				repositoryCommit("chore(deps): Update module x to v2 (#3)", "renovate[bot]"),
+				repositoryCommit("chore: Upgrade framework to v1.16.1 (auto) (#4)", "goravel"),
+			},
+			want: &RepoVersionBump{Repo: "framework", LatestTag: "v1.16.1", Branch: "master", Merged: 2},
+		},
+		{
+			name: "feature",
+			commits: []*github.RepositoryCommit{
+				repositoryCommit("fix: Fix the session driver (#2)", "hwbrzzl"),
+				repositoryCommit("feat(http): Add rate limiter (#3)", "hwbrzzl"),
+				repositoryCommit("feat: Add queue batches", "hwbrzzl"),
+			},
+			want: &RepoVersionBump{
+				Repo:      "framework",
+				LatestTag: "v1.16.1",
+				Branch:    "master",
+				Bump:      BumpMinor,
+				Reasons:   []string{"feat(http): Add rate limiter (#3)", "feat: Add queue batches"},
+				Merged:    3,
+			},
+		},
+		{
+			name: "breaking change",
+			commits: []*github.RepositoryCommit{
+				repositoryCommit("feat(http): Add rate limiter (#3)", "hwbrzzl"),
+				repositoryCommit("Merge pull request #4 from goravel/remove\n\nfeat!: Remove the deprecated methods", "hwbrzzl"),
+				repositoryCommit("fix: Rename the option (#5)\n\nBREAKING CHANGE: the option is renamed", "hwbrzzl"),
+			},
+			want: &RepoVersionBump{
+				Repo:      "framework",
+				LatestTag: "v1.16.1",
+				Branch:    "master",
+				Bump:      BumpMajor,
+				Reasons:   []string{"feat(http): Add rate limiter (#3)", "feat!: Remove the deprecated methods (#4)", "fix: Rename the option (#5)"},
+				Merged:    3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newRepoVersionBump("framework", "v1.16.1", "master", tt.commits))
+		})
+	}
+}
+
+func TestCommitVersionBump(t *testing.T) {
+	tests := []struct {
+		message string
+		want    VersionBump
+	}{
+		{message: "fix: Correct the timezone (#1)", want: BumpPatch},
+		{message: "chore: Update the dependencies (#2)", want: BumpPatch},
+		{message: "feat(http): Add rate limiter (#3)", want: BumpMinor},
+		{message: "feat!: Remove the deprecated methods (#4)", want: BumpMajor},
+		{message: "fix: Rename the option (#5)\n\nBREAKING CHANGE: the option is renamed", want: BumpMajor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, commitVersionBump(tt.message))
+		})
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		bump VersionBump
+		want string
+	}{
+		{bump: BumpPatch, want: "v1.16.3"},
+		{bump: BumpMinor, want: "v1.17.0"},
+		{bump: BumpMajor, want: "v2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bump.String(), func(t *testing.T) {
+			version, err := services.ParseVersion("v1.16.2")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, bumpVersion(version, tt.bump).String())
+		})
+	}
+}
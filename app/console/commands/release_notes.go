@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+)
+
+var (
+	// autoPRTitleRegexp matches the titles of the PRs created by this tool, e.g. chore: Upgrade framework to v1.16.0 (auto)
+	autoPRTitleRegexp = regexp.MustCompile(`^chore: (Upgrade framework|Update version) to \S+ \(auto\)$`)
+	// mergeCommitRegexp matches the subject of a merge commit, the PR title is the first line of the body
+	mergeCommitRegexp = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+	// squashCommitRegexp matches the subject of a squashed commit, e.g. feat: Add xxx (#123)
+	squashCommitRegexp = regexp.MustCompile(`^(.+) \(#(\d+)\)$`)
+)
+
+// botAuthors are the bots whose PRs are not listed in the release notes.
+var botAuthors = []string{"renovate", "dependabot", "github-actions"}
+
+// buildReleaseNotes builds the release notes from the PRs merged between the previous tag and the tag, the PRs are
+// found from the squashed or merge commits and grouped by the conventional commit type. The PRs of bots and of this
+// tool are skipped. The New Contributors section of the notes generated by GitHub is kept.
+func buildReleaseNotes(owner, repo, previousTag, tag string, commits []*github.RepositoryCommit, generated string) string {
+	var (
+		numbers      []string
+		groupToLines = make(map[ChangelogGroup][]string)
+	)
+
+	for _, commit := range commits {
+		title, number := releaseNotesPR(commit.GetCommit().GetMessage())
+		author := commit.GetAuthor().GetLogin()
+		if number == "" || slices.Contains(numbers, number) || isBot(author) || autoPRTitleRegexp.MatchString(title) {
+			continue
+		}
+		numbers = append(numbers, number)
+
+		line := "* " + title
+		if author != "" {
+			line += " by @" + author
+		}
+		line += fmt.Sprintf(" in https://github.com/%s/%s/pull/%s", owner, repo, number)
+
+		group := newChangelogEntry("", title).Group
+		groupToLines[group] = append(groupToLines[group], line)
+	}
+
+	var sections []string
+	if len(numbers) > 0 {
+		sections = append(sections, "## What's Changed")
+		for _, group := range changelogGroups {
+			if lines := groupToLines[group]; len(lines) > 0 {
+				sections = append(sections, fmt.Sprintf("### %s\n%s", group, strings.Join(lines, "\n")))
+			}
+		}
+	}
+	if contributors := newContributors(generated); contributors != "" {
+		sections = append(sections, contributors)
+	}
+	sections = append(sections, fmt.Sprintf("**Full Changelog**: https://github.com/%s/%s/compare/%s...%s", owner, repo, previousTag, tag))
+
+	return strings.Join(sections, "\n\n")
+}
+
+func isBot(author string) bool {
+	return strings.HasSuffix(author, "[bot]") || slices.Contains(botAuthors, author)
+}
+
+// newContributors returns the New Contributors section of the notes generated by GitHub, it's empty if there is none.
+func newContributors(generated string) string {
+	var (
+		lines   []string
+		section bool
+	)
+	for _, line := range strings.Split(generated, "\n") {
+		switch {
+		case strings.HasPrefix(line, "## New Contributors"):
+			section = true
+		case strings.HasPrefix(line, "## "), strings.HasPrefix(line, "**Full Changelog**"):
+			section = false
+		}
+
+		if section {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// releaseNotesPR returns the title and the number of the PR the commit is merged from, the number is empty
+// if the commit is pushed directly.
+func releaseNotesPR(message string) (string, string) {
+	subject, body, _ := strings.Cut(message, "\n")
+
+	if matches := mergeCommitRegexp.FindStringSubmatch(subject); matches != nil {
+		title := matches[0]
+		for _, line := range strings.Split(body, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				title = line
+				break
+			}
+		}
+
+		return title, matches[1]
+	}
+
+	if matches := squashCommitRegexp.FindStringSubmatch(subject); matches != nil {
+		return matches[1], matches[2]
+	}
+
+	return subject, ""
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReleaseNotes(t *testing.T) {
+	commits := []*github.RepositoryCommit{
+		repositoryCommit("perf(orm): Cache the model fields (#5)", "bob"),
+		repositoryCommit("feat!: Remove the deprecated methods (#6)", "hwbrzzl"),
+		repositoryCommit("docs: Update README (#7)", "alice"),
+		repositoryCommit("chore(deps): Bump golang.org/x/mod (#8)", "alice"),
+		repositoryCommit("Add a comment (#9)", "alice"),
+		repositoryCommit("Fix typo", "alice"),
+		repositoryCommit("chore: Update version to v1.17.0 (auto) (#10)", "hwbrzzl"),
+		repositoryCommit("chore(deps): update dependency go to v1.25.1 (#11)", "renovate"),
+		repositoryCommit("ci: Update workflows (#12)", "github-actions[bot]"),
+		repositoryCommit("feat!: Remove the deprecated methods (#6)", "hwbrzzl"),
+	}
+
+	assert.Equal(t, `## What's Changed
+
+### Breaking Changes
+* feat!: Remove the deprecated methods by @hwbrzzl in https://github.com/goravel/framework/pull/6
+
+### Performance
+* perf(orm): Cache the model fields by @bob in https://github.com/goravel/framework/pull/5
+
+### Chores
+* docs: Update README by @alice in https://github.com/goravel/framework/pull/7
+
+### Dependencies
+* chore(deps): Bump golang.org/x/mod by @alice in https://github.com/goravel/framework/pull/8
+
+### Other Changes
+* Add a comment by @alice in https://github.com/goravel/framework/pull/9
+
+**Full Changelog**: https://github.com/goravel/framework/compare/v1.16.0...v1.17.0`, buildReleaseNotes("goravel", "framework", "v1.16.0", "v1.17.0", commits, ""))
+
+	assert.Equal(t, `## New Contributors
+* @bob made their first contribution in https://github.com/goravel/framework/pull/5
+
+**Full Changelog**: https://github.com/goravel/framework/compare/v1.16.0...v1.16.1`, buildReleaseNotes("goravel", "framework", "v1.16.0", "v1.16.1", nil, `## What's Changed
+* perf(orm): Cache the model fields by @bob in https://github.com/goravel/framework/pull/5
+
+## New Contributors
+* @bob made their first contribution in https://github.com/goravel/framework/pull/5
+
+**Full Changelog**: https://github.com/goravel/framework/compare/v1.16.0...v1.16.1`))
+}
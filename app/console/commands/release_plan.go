@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v84/github"
+
+	"goravel/app/services"
+)
+
+// ReleasePlan is the resolved information of a major or patch release. It's written by the plan command and
+// carried out as is by the apply command, so the reviewed information can't drift before the release.
+type ReleasePlan struct {
+	// The command the plan is resolved for, major, patch or rc
+	Command string `json:"command"`
+	// The tag to release
+	Tag string `json:"tag"`
+	// The version branch to push, e.g. v1.16.x, it's empty if no branch is pushed
+	Branch string `json:"branch,omitempty"`
+	// The repos in release order, the repos in a level only depend on the repos in the previous levels
+	Levels [][]*ReleasePlanRepo `json:"levels"`
+}
+
+type ReleasePlanRepo struct {
+	// The repo owner
+	Owner string `json:"owner"`
+	// The repo name
+	Repo string `json:"repo"`
+	// Whether the repo is upgraded by its own workflow, its release can only be resolved after the upgrade is merged
+	AutoUpgrade bool `json:"auto_upgrade,omitempty"`
+	// Whether to skip creating the GitHub release
+	SkipRelease bool `json:"skip_release,omitempty"`
+	// Whether to push the version branch
+	PushBranch bool `json:"push_branch,omitempty"`
+	// Whether to set the version branch as the default branch
+	DefaultBranch bool `json:"default_branch,omitempty"`
+	// The upgrade PR to create before the release, nil if the repo doesn't need to be upgraded
+	Upgrade *ReleasePlanUpgrade `json:"upgrade,omitempty"`
+	// The release to create, nil if the release is skipped or the repo is auto upgraded
+	Release *ReleasePlanRelease `json:"release,omitempty"`
+}
+
+type ReleasePlanUpgrade struct {
+	// The branch the upgrade PR is based on
+	Base string `json:"base"`
+	// The upgrade branch
+	Head string `json:"head"`
+	// The PR title
+	Title string `json:"title"`
+	// The commands to upgrade the dependencies
+	Commands []string `json:"commands"`
+	// The dependencies and the branches they are released from, see UpgradeInformation
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+type ReleasePlanRelease struct {
+	// The tag to release
+	Tag string `json:"tag"`
+	// The previous tag the notes are generated against
+	PreviousTag string `json:"previous_tag"`
+	// The Version constant in code, only the repos declaring version_file in the manifest have it
+	CurrentTag string `json:"current_tag,omitempty"`
+	// The branch the release targets
+	Branch string `json:"branch"`
+	// The release name
+	Name string `json:"name"`
+	// The release notes
+	Body string `json:"body"`
+	// The incompatible API changes against the previous tag, only the framework and the packages are checked
+	IncompatibleChanges []string `json:"incompatible_changes,omitempty"`
+}
+
+func LoadReleasePlan(path string) (*ReleasePlan, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("release plan %s does not exist", path)
+		}
+
+		return nil, fmt.Errorf("failed to read release plan %s: %w", path, err)
+	}
+
+	var plan ReleasePlan
+	if err := json.Unmarshal(content, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse release plan %s: %w", path, err)
+	}
+
+	if err := plan.validate(); err != nil {
+		return nil, fmt.Errorf("invalid release plan %s: %w", path, err)
+	}
+
+	return &plan, nil
+}
+
+func newReleasePlanRepo(repo *Repo, upgrade *UpgradeInformation, releaseInfo *ReleaseInformation, branch bool) *ReleasePlanRepo {
+	planRepo := &ReleasePlanRepo{
+		Owner:         repo.Owner,
+		Repo:          repo.Name,
+		AutoUpgrade:   repo.AutoUpgrade,
+		SkipRelease:   repo.SkipRelease,
+		PushBranch:    branch && repo.Branch,
+		DefaultBranch: branch && repo.DefaultBranch,
+	}
+
+	if upgrade != nil {
+		planRepo.Upgrade = &ReleasePlanUpgrade{
+			Base:         upgrade.baseBranch,
+			Head:         upgrade.branch,
+			Title:        upgrade.title,
+			Commands:     upgrade.commands,
+			Dependencies: upgrade.dependencies,
+		}
+	}
+
+	if releaseInfo != nil {
+		planRepo.Release = &ReleasePlanRelease{
+			Tag:                 releaseInfo.tag,
+			PreviousTag:         releaseInfo.latestTag,
+			CurrentTag:          releaseInfo.currentTag,
+			Branch:              releaseInfo.branch,
+			Name:                releaseInfo.notes.Name,
+			Body:                releaseInfo.notes.Body,
+			IncompatibleChanges: releaseInfo.incompatibleChanges,
+		}
+	}
+
+	return planRepo
+}
+
+// ReleaseInformation returns the release information of the repos released with resolved notes, keyed by repo.
+func (r *ReleasePlan) ReleaseInformation() map[string]*ReleaseInformation {
+	repoToReleaseInfo := make(map[string]*ReleaseInformation)
+	for _, level := range r.Levels {
+		for _, repo := range level {
+			if releaseInfo := repo.ReleaseInformation(); releaseInfo != nil {
+				repoToReleaseInfo[repo.Repo] = releaseInfo
+			}
+		}
+	}
+
+	return repoToReleaseInfo
+}
+
+// ReleasedTags returns the tags of the repos released by the plan, keyed by repo. The auto upgrade repos aren't
+// included given their releases are resolved during the release.
+func (r *ReleasePlan) ReleasedTags() map[string]string {
+	repoToTag := make(map[string]string)
+	for _, level := range r.Levels {
+		for _, repo := range level {
+			if repo.Release != nil {
+				repoToTag[repo.Repo] = repo.Release.Tag
+			}
+		}
+	}
+
+	return repoToTag
+}
+
+func (r *ReleasePlan) Save(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode release plan: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create release plan directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to save release plan %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (r *ReleasePlan) validate() error {
+	if r.Command != "major" && r.Command != "patch" && r.Command != "rc" {
+		return fmt.Errorf("command %q is invalid, it should be one of major, patch and rc", r.Command)
+	}
+	if r.Tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+	if _, err := services.ParseVersion(r.Tag); err != nil {
+		return err
+	}
+
+	for _, level := range r.Levels {
+		for _, repo := range level {
+			if repo.Owner == "" || repo.Repo == "" {
+				return fmt.Errorf("owner and repo are required for every repo")
+			}
+			if !repo.AutoUpgrade && !repo.SkipRelease && repo.Release == nil {
+				return fmt.Errorf("release of %s/%s is missing", repo.Owner, repo.Repo)
+			}
+			if repo.Upgrade != nil && (repo.Upgrade.Base == "" || repo.Upgrade.Head == "" || repo.Upgrade.Title == "") {
+				return fmt.Errorf("upgrade of %s/%s requires base, head and title", repo.Owner, repo.Repo)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReleaseInformation returns the resolved release information, nil if the repo has no resolved release.
+func (r *ReleasePlanRepo) ReleaseInformation() *ReleaseInformation {
+	if r.Release == nil {
+		return nil
+	}
+
+	return &ReleaseInformation{
+		branch:              r.Release.Branch,
+		currentTag:          r.Release.CurrentTag,
+		incompatibleChanges: r.Release.IncompatibleChanges,
+		latestTag:           r.Release.PreviousTag,
+		notes: &github.RepositoryReleaseNotes{
+			Name: r.Release.Name,
+			Body: r.Release.Body,
+		},
+		repo: r.Repo,
+		tag:  r.Release.Tag,
+	}
+}
+
+// UpgradeInformation returns the upgrade PR to create, nil if the repo doesn't need to be upgraded.
+func (r *ReleasePlanRepo) UpgradeInformation() *UpgradeInformation {
+	if r.Upgrade == nil {
+		return nil
+	}
+
+	return &UpgradeInformation{
+		repo:         r.Repo,
+		baseBranch:   r.Upgrade.Base,
+		branch:       r.Upgrade.Head,
+		title:        r.Upgrade.Title,
+		commands:     r.Upgrade.Commands,
+		dependencies: r.Upgrade.Dependencies,
+	}
+}
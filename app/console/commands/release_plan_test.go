@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleasePlan(t *testing.T) {
+	framework := &Repo{Name: "framework", Owner: "goravel", Role: RoleFramework, Branch: true, DefaultBranch: true}
+	example := &Repo{Name: "example", Owner: "goravel", Role: RoleApp, Branch: true, SkipRelease: true}
+	upgrade := (&Release{}).newUpgradeInformation("example", "master", "v1.17.0", []string{"go get github.com/goravel/framework@v1.17.0"}, map[string]string{"framework": "v1.17.x"})
+	releaseInfo := &ReleaseInformation{
+		branch:     "master",
+		currentTag: "v1.17.0",
+		latestTag:  "v1.16.0",
+		notes: &github.RepositoryReleaseNotes{
+			Name: "v1.17.0",
+			Body: "## What's Changed",
+		},
+		repo: "framework",
+		tag:  "v1.17.0",
+	}
+
+	plan := &ReleasePlan{
+		Command: "major",
+		Tag:     "v1.17.0",
+		Branch:  "v1.17.x",
+		Levels: [][]*ReleasePlanRepo{
+			{newReleasePlanRepo(framework, nil, releaseInfo, true)},
+			{newReleasePlanRepo(example, upgrade, nil, false)},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, plan.Save(path))
+
+	loaded, err := LoadReleasePlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, plan, loaded)
+	assert.Equal(t, map[string]*ReleaseInformation{"framework": releaseInfo}, loaded.ReleaseInformation())
+	assert.True(t, loaded.Levels[0][0].PushBranch)
+	assert.True(t, loaded.Levels[0][0].DefaultBranch)
+	assert.False(t, loaded.Levels[1][0].PushBranch)
+	assert.Nil(t, loaded.Levels[0][0].UpgradeInformation())
+	assert.Equal(t, upgrade, loaded.Levels[1][0].UpgradeInformation())
+}
+
+func TestLoadReleasePlanValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "invalid command",
+			content: `{"command": "preview", "tag": "v1.17.0"}`,
+			wantErr: `command "preview" is invalid, it should be one of major, patch and rc`,
+		},
+		{
+			name:    "missing tag",
+			content: `{"command": "major"}`,
+			wantErr: "tag is required",
+		},
+		{
+			name:    "invalid tag",
+			content: `{"command": "major", "tag": "v1.17"}`,
+			wantErr: `invalid tag "v1.17", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`,
+		},
+		{
+			name:    "missing repo",
+			content: `{"command": "major", "tag": "v1.17.0", "levels": [[{"owner": "goravel"}]]}`,
+			wantErr: "owner and repo are required for every repo",
+		},
+		{
+			name:    "missing release",
+			content: `{"command": "major", "tag": "v1.17.0", "levels": [[{"owner": "goravel", "repo": "gin"}]]}`,
+			wantErr: "release of goravel/gin is missing",
+		},
+		{
+			name:    "incomplete upgrade",
+			content: `{"command": "patch", "tag": "v1.17.1", "levels": [[{"owner": "goravel", "repo": "example", "skip_release": true, "upgrade": {"base": "v1.17.x"}}]]}`,
+			wantErr: "upgrade of goravel/example requires base, head and title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plan.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := LoadReleasePlan(path)
+			assert.EqualError(t, err, "invalid release plan "+path+": "+tt.wantErr)
+		})
+	}
+
+	t.Run("missing plan", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.json")
+
+		_, err := LoadReleasePlan(path)
+		assert.EqualError(t, err, "release plan "+path+" does not exist")
+	})
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// smokeProject is the name of the project installed by the smoke test.
+const smokeProject = "smoke"
+
+// SmokeStep is a command of the smoke test, the steps run in order and the first failure stops the rest given
+// every step depends on the previous ones.
+type SmokeStep struct {
+	// The step name printed in the summary
+	Name string
+	// The dir to run the command in
+	Dir string
+	// The command to run
+	Command string
+}
+
+// newSmokeSteps returns the steps to build the installer cloned into the installer dir, install a new project into
+// the workspace dir, then build, vet, test and run the artisan of the project.
+func newSmokeSteps(installerDir, workspaceDir string) []*SmokeStep {
+	var (
+		binary     = filepath.Join(workspaceDir, "bin", "goravel")
+		projectDir = filepath.Join(workspaceDir, smokeProject)
+	)
+
+	return []*SmokeStep{
+		{Name: "Build the installer", Dir: installerDir, Command: fmt.Sprintf("go build -o %s .", binary)},
+		{Name: "Install a new project", Dir: workspaceDir, Command: fmt.Sprintf("%s new %s", binary, smokeProject)},
+		{Name: "go build", Dir: projectDir, Command: "go build ./..."},
+		{Name: "go vet", Dir: projectDir, Command: "go vet ./..."},
+		{Name: "go test", Dir: projectDir, Command: "go test ./..."},
+		{Name: "Run artisan", Dir: projectDir, Command: "go run . artisan list"},
+	}
+}
+
+// smokeEnv returns the Go environment of the smoke test, the empty values are inherited from the current process.
+func smokeEnv(goProxy, goFlags string) map[string]string {
+	env := make(map[string]string)
+	if goProxy != "" {
+		env["GOPROXY"] = goProxy
+	}
+	if goFlags != "" {
+		env["GOFLAGS"] = goFlags
+	}
+
+	return env
+}
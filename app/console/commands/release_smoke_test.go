@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSmokeSteps(t *testing.T) {
+	steps := newSmokeSteps("/tmp/smoke/installer", "/tmp/smoke")
+
+	assert.Equal(t, []*SmokeStep{
+		{Name: "Build the installer", Dir: "/tmp/smoke/installer", Command: "go build -o /tmp/smoke/bin/goravel ."},
+		{Name: "Install a new project", Dir: "/tmp/smoke", Command: "/tmp/smoke/bin/goravel new smoke"},
+		{Name: "go build", Dir: "/tmp/smoke/smoke", Command: "go build ./..."},
+		{Name: "go vet", Dir: "/tmp/smoke/smoke", Command: "go vet ./..."},
+		{Name: "go test", Dir: "/tmp/smoke/smoke", Command: "go test ./..."},
+		{Name: "Run artisan", Dir: "/tmp/smoke/smoke", Command: "go run . artisan list"},
+	}, steps)
+}
+
+func TestSmokeEnv(t *testing.T) {
+	assert.Empty(t, smokeEnv("", ""))
+	assert.Equal(t, map[string]string{
+		"GOPROXY": "file:///tmp/proxy",
+		"GOFLAGS": "-mod=mod",
+	}, smokeEnv("file:///tmp/proxy", "-mod=mod"))
+}
@@ -3,13 +3,19 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v84/github"
 	"github.com/goravel/framework/contracts/console"
 	mocksconsole "github.com/goravel/framework/mocks/console"
 	mocksclient "github.com/goravel/framework/mocks/http/client"
 	mocksprocess "github.com/goravel/framework/mocks/process"
+	"github.com/goravel/framework/support/color"
 	"github.com/goravel/framework/support/convert"
 	testingmock "github.com/goravel/framework/testing/mock"
 	"github.com/stretchr/testify/assert"
@@ -17,12 +23,18 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	mocksservices "goravel/app/mocks/services"
+	"goravel/app/services"
 )
 
+const owner = "goravel"
+
 type ReleaseTestSuite struct {
 	suite.Suite
+	mockAPIDiff *mocksservices.APIDiff
 	mockContext *mocksconsole.Context
+	mockGit     *mocksservices.Git
 	mockGithub  *mocksservices.Github
+	mockGoProxy *mocksservices.GoProxy
 	mockProcess *mocksprocess.Process
 	mockHttp    *mocksclient.Factory
 	release     *Release
@@ -33,22 +45,338 @@ func TestReleaseTestSuite(t *testing.T) {
 }
 
 func (s *ReleaseTestSuite) SetupTest() {
-	packages = []string{
-		"gin",
-		"goravel-lite",
-	}
-
 	mockFactory := testingmock.Factory()
+	s.mockAPIDiff = mocksservices.NewAPIDiff(s.T())
 	s.mockContext = mocksconsole.NewContext(s.T())
+	s.mockGit = mocksservices.NewGit(s.T())
 	s.mockGithub = mocksservices.NewGithub(s.T())
+	s.mockGoProxy = mocksservices.NewGoProxy(s.T())
 	s.mockProcess = mockFactory.Process()
 	s.mockHttp = mockFactory.Http()
 
 	s.release = &Release{
-		ctx:    s.mockContext,
-		real:   true,
-		github: s.mockGithub,
+		apiDiff: s.mockAPIDiff,
+		ctx:     s.mockContext,
+		real:    true,
+		git:     s.mockGit,
+		github:  s.mockGithub,
+		goProxy: s.mockGoProxy,
+		manifest: newTestManifest(
+			&Repo{Name: "framework", Role: RoleFramework, Branch: true, VersionFile: "support/constant.go"},
+			&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework"}, Branch: true},
+			&Repo{Name: "goravel-lite", Role: RoleApp, Dependencies: []string{"framework"}, Branch: true, DefaultBranch: true},
+		),
+	}
+}
+
+func (s *ReleaseTestSuite) expectRunInDir(command string, result *mocksprocess.Result) {
+	s.mockProcess.EXPECT().Path(mock.AnythingOfType("string")).Return(s.mockProcess).Once()
+	s.mockProcess.EXPECT().Quietly().Return(s.mockProcess).Once()
+	s.mockProcess.EXPECT().OnOutput(mock.Anything).Return(s.mockProcess).Once()
+	s.mockProcess.EXPECT().Run(command).Return(result).Once()
+}
+
+func repositoryCommit(message, author string) *github.RepositoryCommit {
+	return &github.RepositoryCommit{
+		Author: &github.User{Login: convert.Pointer(author)},
+		Commit: &github.Commit{Message: convert.Pointer(message)},
+	}
+}
+
+func newTestManifest(repos ...*Repo) *Manifest {
+	manifest := &Manifest{
+		Owner: owner,
+		Repos: repos,
+	}
+	if err := manifest.validate(); err != nil {
+		panic(err)
+	}
+
+	return manifest
+}
+
+func (s *ReleaseTestSuite) Test_checkAPICompatibility() {
+	newPlan := func(command string) *ReleasePlan {
+		return &ReleasePlan{
+			Command: command,
+			Tag:     "v1.16.2",
+			Levels: [][]*ReleasePlanRepo{
+				{{Owner: owner, Repo: "framework", Release: &ReleasePlanRelease{Tag: "v1.16.2", PreviousTag: "v1.16.1", Branch: "v1.16.x"}}},
+				{{Owner: owner, Repo: "goravel-lite", Release: &ReleasePlanRelease{Tag: "v1.16.2", PreviousTag: "v1.16.1", Branch: "v1.16.x"}}},
+			},
+		}
+	}
+	expectCheck := func(changes []string, err error, status string) {
+		s.mockContext.EXPECT().Spinner("Checking API compatibility of framework...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(nil).Twice()
+		s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "v1.16.1").Return(nil).Once()
+		s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "v1.16.x").Return(nil).Once()
+		s.mockAPIDiff.EXPECT().Incompatible(mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(changes, err).Once()
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/framework", status).Return().Once()
+	}
+	changes := []string{"./http.Context.Request: removed"}
+
+	s.Run("patch - compatible", func() {
+		plan := newPlan("patch")
+		expectCheck(nil, nil, color.Green().Sprint("PASS"))
+
+		s.NoError(s.release.checkAPICompatibility(plan))
+		s.Nil(plan.Levels[0][0].Release.IncompatibleChanges)
+	})
+
+	s.Run("patch - blocked by incompatible changes", func() {
+		plan := newPlan("patch")
+		expectCheck(changes, nil, color.Green().Sprint("PASS"))
+		s.mockContext.EXPECT().OptionBool("allow-breaking").Return(false).Once()
+
+		s.EqualError(s.release.checkAPICompatibility(plan), "incompatible API changes found in framework, a patch release should not break the API, pass --allow-breaking to release anyway")
+		s.Equal(changes, plan.Levels[0][0].Release.IncompatibleChanges)
+	})
+
+	s.Run("patch - incompatible changes allowed", func() {
+		plan := newPlan("patch")
+		expectCheck(changes, nil, color.Green().Sprint("PASS"))
+		s.mockContext.EXPECT().OptionBool("allow-breaking").Return(true).Once()
+
+		s.NoError(s.release.checkAPICompatibility(plan))
+		s.Equal(changes, plan.Levels[0][0].Release.IncompatibleChanges)
+	})
+
+	s.Run("major - incompatible changes are listed only", func() {
+		plan := newPlan("major")
+		expectCheck(changes, nil, color.Green().Sprint("PASS"))
+
+		s.NoError(s.release.checkAPICompatibility(plan))
+		s.Equal(changes, plan.ReleaseInformation()["framework"].incompatibleChanges)
+	})
+
+	s.Run("failed to check", func() {
+		plan := newPlan("patch")
+		expectCheck(nil, assert.AnError, color.Red().Sprint("FAIL"))
+
+		s.EqualError(s.release.checkAPICompatibility(plan), fmt.Sprintf("failed to check API compatibility of framework between v1.16.1 and v1.16.x: %s", assert.AnError))
+	})
+
+	s.Run("first release - nothing to check", func() {
+		plan := newPlan("patch")
+		plan.Levels[0][0].Release.PreviousTag = ""
+
+		s.NoError(s.release.checkAPICompatibility(plan))
+	})
+}
+
+func (s *ReleaseTestSuite) Test_checkAutoUpgradePR() {
+	var (
+		repo         = &ReleasePlanRepo{Owner: owner, Repo: "goravel", AutoUpgrade: true}
+		releasedTags = map[string]string{"framework": "v1.17.0", "gin": "v1.17.0"}
+		listOptions  = &github.PullRequestListOptions{State: "all", Base: "master", ListOptions: github.ListOptions{PerPage: 100}}
+		pr           = func(merged bool) *github.PullRequest {
+			return &github.PullRequest{
+				Number:  convert.Pointer(2),
+				Title:   convert.Pointer("chore: Upgrade framework to v1.17.0"),
+				State:   convert.Pointer(map[bool]string{true: "closed", false: "open"}[merged]),
+				Merged:  convert.Pointer(merged),
+				HTMLURL: convert.Pointer("https://github.com/goravel/goravel/pull/2"),
+				Head:    &github.PullRequestBranch{Ref: convert.Pointer("upgrade/v1.17.0")},
+			}
+		}
+		otherPR = &github.PullRequest{
+			Number: convert.Pointer(1),
+			Title:  convert.Pointer("chore: Upgrade framework to v1.16.2"),
+			State:  convert.Pointer("closed"),
+			Merged: convert.Pointer(true),
+		}
+		expectGoMod = func(content string) {
+			mockResponse := mocksclient.NewResponse(s.T())
+			mockResponse.EXPECT().Failed().Return(false)
+			mockResponse.EXPECT().Body().Return(content, nil)
+			s.mockHttp.EXPECT().Get("https://raw.githubusercontent.com/goravel/goravel/refs/heads/master/go.mod").Return(mockResponse, nil)
+		}
+	)
+
+	beforeEach := func() {
+		s.SetupTest()
+		s.release.manifest = newTestManifest(
+			&Repo{Name: "framework", Role: RoleFramework},
+			&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework"}},
+			&Repo{Name: "goravel", Role: RoleApp, Dependencies: []string{"framework", "gin"}, AutoUpgrade: true},
+		)
+		s.release.mergeTimeout = time.Minute
+		s.release.pollInterval = time.Millisecond
+	}
+
+	s.Run("wait until the PR is merged", func() {
+		beforeEach()
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel", "v1.17.x").Return(false, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, "goravel", listOptions).Return([]*github.PullRequest{pr(false), otherPR}, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequestFiles(owner, "goravel", 2).Return([]*github.CommitFile{
+			{Filename: convert.Pointer("go.mod"), Additions: convert.Pointer(2), Deletions: convert.Pointer(2)},
+		}, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, "goravel", listOptions).Return([]*github.PullRequest{pr(true), otherPR}, nil).Once()
+		expectGoMod("module goravel\n\nrequire (\n\tgithub.com/goravel/framework v1.17.0\n\tgithub.com/goravel/gin v1.17.0\n)\n")
+
+		s.NoError(s.release.checkAutoUpgradePR(repo, "v1.17.0", releasedTags))
+	})
+
+	s.Run("go.mod doesn't require the released tags", func() {
+		beforeEach()
+		s.release.mergeTimeout = 5 * time.Millisecond
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel", "v1.17.x").Return(false, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, "goravel", listOptions).Return([]*github.PullRequest{pr(true)}, nil)
+		s.mockGithub.EXPECT().GetPullRequestFiles(owner, "goravel", 2).Return(nil, nil).Once()
+		expectGoMod("module goravel\n\nrequire (\n\tgithub.com/goravel/framework v1.17.0\n\tgithub.com/goravel/gin v1.16.0\n)\n")
+
+		err := s.release.checkAutoUpgradePR(repo, "v1.17.0", releasedTags)
+
+		s.EqualError(err, "goravel/goravel: go.mod at master doesn't require the released tags: github.com/goravel/gin v1.16.0, want v1.17.0 after 5ms")
+		s.Equal(ExitCodePending, ExitCode(err))
+	})
+
+	s.Run("the PR is not found", func() {
+		beforeEach()
+		s.release.mergeTimeout = 5 * time.Millisecond
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel", "v1.17.x").Return(false, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, "goravel", listOptions).Return([]*github.PullRequest{otherPR}, nil)
+
+		err := s.release.checkAutoUpgradePR(repo, "v1.17.0", releasedTags)
+
+		s.EqualError(err, "goravel/goravel: the auto upgrade PR to v1.17.0 is not found against master after 5ms")
+		s.Equal(ExitCodePending, ExitCode(err))
+	})
+
+	s.Run("failed to list the PRs", func() {
+		beforeEach()
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel", "v1.17.x").Return(false, nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, "goravel", listOptions).Return(nil, errors.New("error")).Once()
+
+		s.EqualError(s.release.checkAutoUpgradePR(repo, "v1.17.0", releasedTags), "error")
+	})
+
+	s.Run("skipped in preview mode", func() {
+		beforeEach()
+		s.release.real = false
+
+		s.NoError(s.release.checkAutoUpgradePR(repo, "v1.17.0", releasedTags))
+	})
+}
+
+func (s *ReleaseTestSuite) Test_checkCIStatus() {
+	checkRun := func(name, status, conclusion string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       convert.Pointer(name),
+			Status:     convert.Pointer(status),
+			Conclusion: convert.Pointer(conclusion),
+			HTMLURL:    convert.Pointer("https://github.com/goravel/framework/runs/" + name),
+		}
 	}
+	combinedStatus := &github.CombinedStatus{Statuses: []*github.RepoStatus{
+		{Context: convert.Pointer("codecov/patch"), State: convert.Pointer("success")},
+	}}
+
+	s.Run("all checks passed", func() {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(0).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(combinedStatus, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "completed", "success"),
+			checkRun("lint", "completed", "skipped"),
+		}, nil).Once()
+
+		s.NoError(s.release.checkCIStatus("framework", "v1.16.x"))
+	})
+
+	s.Run("pending checks block the release without waiting", func() {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(0).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(combinedStatus, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "in_progress", ""),
+		}, nil).Once()
+
+		s.EqualError(s.release.checkCIStatus("framework", "v1.16.x"), "the checks of goravel/framework at v1.16.x are pending: test, pass --wait-checks to wait for them")
+	})
+
+	s.Run("wait for the pending checks", func() {
+		s.release.pollInterval = time.Millisecond
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(1).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, nil).Twice()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(combinedStatus, nil).Twice()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "queued", ""),
+		}, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "completed", "success"),
+		}, nil).Once()
+
+		s.NoError(s.release.checkCIStatus("framework", "v1.16.x"))
+	})
+
+	s.Run("required checks", func() {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(0).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return([]string{"test", "codecov/patch", "lint"}, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(combinedStatus, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "completed", "success"),
+			checkRun("benchmark", "completed", "failure"),
+		}, nil).Once()
+
+		s.EqualError(s.release.checkCIStatus("framework", "v1.16.x"), "the checks of goravel/framework at v1.16.x are pending: lint, pass --wait-checks to wait for them")
+	})
+
+	s.Run("failing checks", func() {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(10).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(&github.CombinedStatus{Statuses: []*github.RepoStatus{
+			{Context: convert.Pointer("codecov/patch"), State: convert.Pointer("error")},
+		}}, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "completed", "timed_out"),
+			checkRun("lint", "in_progress", ""),
+		}, nil).Once()
+
+		s.EqualError(s.release.checkCIStatus("framework", "v1.16.x"), "the checks of goravel/framework at v1.16.x are failing: codecov/patch, test")
+	})
+
+	s.Run("failed to get the checks", func() {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(0).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, assert.AnError).Once()
+
+		s.ErrorIs(s.release.checkCIStatus("framework", "v1.16.x"), assert.AnError)
+	})
+
+	s.Run("preview records the checks without waiting or failing", func() {
+		s.release.real = false
+		s.release.plan = services.NewPlan()
+		defer func() {
+			s.release.real = true
+			s.release.plan = nil
+		}()
+
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "v1.16.x").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "v1.16.x").Return(combinedStatus, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "v1.16.x").Return([]*github.CheckRun{
+			checkRun("test", "completed", "failure"),
+			checkRun("lint", "in_progress", ""),
+		}, nil).Once()
+
+		s.NoError(s.release.checkCIStatus("framework", "v1.16.x"))
+		s.Equal([]*services.PlanAction{
+			{
+				Type:  services.PlanActionCheckStatus,
+				Owner: owner,
+				Repo:  "framework",
+				Payload: &services.CheckStatusPayload{
+					Branch:  "v1.16.x",
+					Failing: []string{"test"},
+					Pending: []string{"lint"},
+				},
+			},
+		}, s.release.plan.Actions)
+	})
 }
 
 func (s *ReleaseTestSuite) Test_checkPRMergeStatus() {
@@ -76,16 +404,10 @@ func (s *ReleaseTestSuite) Test_checkPRMergeStatus() {
 			wantResult: true,
 		},
 		{
-			name: "happy path - not real",
-			real: false,
-			pr:   pr,
-			setup: func() {
-				s.mockGithub.EXPECT().GetPullRequest(owner, repo, *pr.Number).Return(&github.PullRequest{
-					Number:  convert.Pointer(1),
-					HTMLURL: convert.Pointer("https://github.com/goravel/gin/pull/1"),
-					Merged:  convert.Pointer(true),
-				}, nil).Once()
-			},
+			name:       "happy path - not real, the planned PR is considered merged",
+			real:       false,
+			pr:         pr,
+			setup:      func() {},
 			wantResult: true,
 		},
 		{
@@ -399,6 +721,56 @@ func (s *ReleaseTestSuite) Test_checkPRsMergeStatus() {
 	}
 }
 
+func (s *ReleaseTestSuite) Test_cloneURL() {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name: "default",
+			want: "git@github.com:goravel/gin.git",
+		},
+		{
+			name:     "owner and repo",
+			template: "https://github.com/%s/%s.git",
+			want:     "https://github.com/goravel/gin.git",
+		},
+		{
+			name:     "owner/repo",
+			template: "file:///tmp/fixtures/%s.git",
+			want:     "file:///tmp/fixtures/goravel/gin.git",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.release.cloneURLTemplate = tt.template
+
+			s.Equal(tt.want, s.release.cloneURL("gin"))
+		})
+	}
+}
+
+func (s *ReleaseTestSuite) Test_confirm() {
+	s.Run("ask in interactive mode", func() {
+		s.mockContext.EXPECT().Confirm("Did you test in sub-packages?").Return(true).Once()
+
+		s.True(s.release.confirm("Did you test in sub-packages?", false))
+	})
+
+	s.Run("answer with the policy answer in non-interactive mode", func() {
+		s.release.nonInteractive = true
+		defer func() {
+			s.release.nonInteractive = false
+		}()
+
+		s.False(s.release.confirm("Did you test in sub-packages?", false))
+		s.True(s.release.confirm("goravel/framework confirmed?", true))
+	})
+
+}
+
 func (s *ReleaseTestSuite) Test_createRelease() {
 	var (
 		repo   = "goravel-lite"
@@ -420,7 +792,6 @@ func (s *ReleaseTestSuite) Test_createRelease() {
 			name: "happy path - not real",
 			real: false,
 			setup: func() {
-				s.mockGithub.EXPECT().CheckBranchExists(owner, repo, branch).Return(true, nil).Once()
 				s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
 					TagName:         convert.Pointer(tag),
 					TargetCommitish: convert.Pointer(branch),
@@ -433,7 +804,6 @@ func (s *ReleaseTestSuite) Test_createRelease() {
 			name: "happy path - real",
 			real: true,
 			setup: func() {
-				s.mockGithub.EXPECT().CheckBranchExists(owner, repo, branch).Return(true, nil).Once()
 				s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
 					TagName:         convert.Pointer(tag),
 					TargetCommitish: convert.Pointer(branch),
@@ -446,7 +816,6 @@ func (s *ReleaseTestSuite) Test_createRelease() {
 			name: "failed to create release",
 			real: true,
 			setup: func() {
-				s.mockGithub.EXPECT().CheckBranchExists(owner, repo, branch).Return(true, nil).Once()
 				s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
 					TagName:         convert.Pointer(tag),
 					TargetCommitish: convert.Pointer(branch),
@@ -464,287 +833,238 @@ func (s *ReleaseTestSuite) Test_createRelease() {
 
 			tt.setup()
 
-			err := s.release.createRelease(repo, tag, notes)
+			err := s.release.createRelease(repo, tag, branch, notes)
 
 			s.Equal(tt.wantErr, err)
 		})
 	}
+
+	s.Run("prerelease", func() {
+		s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
+			TagName:         convert.Pointer("v1.1.0-rc.1"),
+			TargetCommitish: convert.Pointer("master"),
+			Name:            convert.Pointer(notes.Name),
+			Body:            convert.Pointer(notes.Body),
+			Prerelease:      convert.Pointer(true),
+		}).Return(nil, nil).Once()
+
+		s.NoError(s.release.createRelease(repo, "v1.1.0-rc.1", "master", notes))
+	})
+
+	s.Run("recorded in the state", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", tag)
+		s.mockGithub.EXPECT().CreateRelease(owner, repo, mock.Anything).Return(&github.RepositoryRelease{ID: convert.Pointer(int64(7))}, nil).Once()
+
+		s.NoError(s.release.createRelease(repo, tag, branch, notes))
+		s.Equal([]*CreatedResource{{Kind: CreatedRelease, Repo: repo, Name: tag, ID: 7}}, s.release.state.Created)
+	})
+
+	s.release.state = nil
 }
 
 func (s *ReleaseTestSuite) Test_createUpgradePR() {
 	var (
-		repo          = "example"
-		frameworkTag  = "v1.16.0"
-		dependencies  = []string{"go get github.com/goravel/framework@v1.16.0", "go get github.com/goravel/gin@v1.4.0"}
-		upgradeBranch = "auto-upgrade/v1.16.0"
-		prTitle       = "chore: Upgrade framework to v1.16.0 (auto)"
+		repo               = "example"
+		frameworkTag       = "v1.16.0"
+		dependencies       = []string{"go get github.com/goravel/framework@v1.16.0", "go get github.com/goravel/gin@v1.4.0"}
+		dependencyBranches = map[string]string{"framework": "v1.16.x", "gin": "master"}
+		dir                = s.T().TempDir()
+		upgradeBranch      = "auto-upgrade/v1.16.0"
+		prTitle            = "chore: Upgrade framework to v1.16.0 (auto)"
+		gitErr             = &services.GitError{Command: "clone", Dir: dir, Err: assert.AnError}
 	)
 
+	// Mock the repo is cloned, checked out and the dependencies are upgraded
+	mockUpgrade := func() {
+		s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+		s.mockGit.EXPECT().Checkout(dir, "master").Return(nil).Once()
+		s.mockGit.EXPECT().CreateBranch(dir, upgradeBranch).Return(nil).Once()
+
+		mockProcessResult := mocksprocess.NewResult(s.T())
+		mockProcessResult.EXPECT().Failed().Return(false).Once()
+		s.expectRunInDir("go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy", mockProcessResult)
+	}
+
+	// Mock the upgrade is committed and pushed
+	mockPush := func() {
+		mockUpgrade()
+		s.mockGit.EXPECT().DiffStat(dir).Return("go.mod | 2 +-", nil).Once()
+		s.mockGit.EXPECT().Commit(dir, prTitle).Return(nil).Once()
+		s.mockGit.EXPECT().Push(dir, upgradeBranch, true).Return(nil).Once()
+	}
+
 	tests := []struct {
-		name    string
-		real    bool
-		setup   func()
-		wantPR  *github.PullRequest
-		wantErr error
+		name        string
+		real        bool
+		setup       func()
+		wantPR      *github.PullRequest
+		wantActions []*services.PlanAction
+		wantErr     error
 	}{
 		{
-			name: "preview mode - returns mock PR",
+			name: "preview mode - clone dependency fails",
 			real: false,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(dir, "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(dir, upgradeBranch).Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(gitErr).Once()
+			},
+			wantErr: fmt.Errorf("failed to clone framework: %w", gitErr),
+		},
+		{
+			name: "preview mode - records the push in the plan",
+			real: false,
+			setup: func() {
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(dir, "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(dir, upgradeBranch).Return(nil).Once()
+
+				// The tags don't exist yet, the dependencies are replaced with the branches they are released from
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "v1.16.x").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/gin.git", mock.AnythingOfType("string")).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "master").Return(nil).Once()
+				mockProcessResult := mocksprocess.NewResult(s.T())
+				mockProcessResult.EXPECT().Failed().Return(false).Once()
+				s.mockProcess.EXPECT().Path(dir).Return(s.mockProcess).Once()
+				s.mockProcess.EXPECT().Quietly().Return(s.mockProcess).Once()
+				s.mockProcess.EXPECT().OnOutput(mock.Anything).Return(s.mockProcess).Once()
+				s.mockProcess.EXPECT().Run(mock.MatchedBy(func(command string) bool {
+					return regexp.MustCompile(`^go mod edit -replace=github.com/goravel/framework=\S+goravel-release-framework-\S+ && ` +
+						`go mod edit -replace=github.com/goravel/gin=\S+goravel-release-gin-\S+ && go mod tidy$`).MatchString(command)
+				})).Return(mockProcessResult).Once()
+
+				s.mockGit.EXPECT().DiffStat(dir).Return("go.mod | 2 +-", nil).Once()
+				s.mockGit.EXPECT().Commit(dir, prTitle).Return(nil).Once()
+				s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
+					State: "open",
+				}).Return(nil, nil).Once()
+				s.mockGithub.EXPECT().CreatePullRequest(owner, repo, &github.NewPullRequest{
+					Title: convert.Pointer(prTitle),
+					Head:  convert.Pointer(upgradeBranch),
+					Base:  convert.Pointer("master"),
+				}).Return(&github.PullRequest{
+					Title:   convert.Pointer(prTitle),
+					HTMLURL: convert.Pointer("https://github.com/goravel/example/compare/master...auto-upgrade/v1.16.0"),
+				}, nil).Once()
 			},
 			wantPR: &github.PullRequest{
 				Title:   convert.Pointer(prTitle),
-				HTMLURL: convert.Pointer("https://github.com/goravel/example/pull/auto-upgrade/v1.16.0"),
-				Number:  convert.Pointer(1),
+				HTMLURL: convert.Pointer("https://github.com/goravel/example/compare/master...auto-upgrade/v1.16.0"),
+			},
+			wantActions: []*services.PlanAction{
+				{
+					Type:  services.PlanActionPushBranch,
+					Owner: owner,
+					Repo:  repo,
+					Payload: &services.PushBranchPayload{
+						Branch:   upgradeBranch,
+						Base:     "master",
+						Force:    true,
+						DiffStat: "go.mod | 2 +-",
+						Commands: dependencies,
+					},
+				},
 			},
-			wantErr: nil,
 		},
 		{
-			name: "real mode - spinner fails",
+			name: "real mode - clone fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					Return(assert.AnError).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(gitErr).Once()
 			},
-			wantPR:  nil,
-			wantErr: assert.AnError,
+			wantErr: fmt.Errorf("failed to clone example: %w", gitErr),
 		},
 		{
-			name: "real mode - clone and mod fails",
+			name: "real mode - checkout fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock the first process.Run call (clone and mod) to fail
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(true).Once()
-				mockProcessResult.EXPECT().Error().Return(assert.AnError).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(dir, "master").Return(gitErr).Once()
 			},
-			wantPR:  nil,
-			wantErr: fmt.Errorf("failed to clone repo and mod for example: %w", assert.AnError),
+			wantErr: fmt.Errorf("failed to checkout master for example: %w", gitErr),
 		},
 		{
-			name: "real mode - check status fails",
+			name: "real mode - create branch fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(dir, "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(dir, upgradeBranch).Return(gitErr).Once()
+			},
+			wantErr: fmt.Errorf("failed to create upgrade branch for example: %w", gitErr),
+		},
+		{
+			name: "real mode - upgrade dependencies fails",
+			real: true,
+			setup: func() {
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/example.git", dir).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(dir, "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(dir, upgradeBranch).Return(nil).Once()
 
-				// Mock successful clone and mod
 				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status fails
-				mockProcessResult = mocksprocess.NewResult(s.T())
 				mockProcessResult.EXPECT().Failed().Return(true).Once()
 				mockProcessResult.EXPECT().Error().Return(assert.AnError).Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				s.expectRunInDir("go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy", mockProcessResult)
 			},
-			wantPR:  nil,
-			wantErr: fmt.Errorf("failed to check status for example: %w", assert.AnError),
+			wantErr: fmt.Errorf("failed to upgrade dependencies for example: %w", assert.AnError),
 		},
 		{
-			name: "real mode - working tree clean, no changes needed",
+			name: "real mode - get changes fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns clean working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("nothing to commit, working tree clean").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				mockUpgrade()
+				s.mockGit.EXPECT().DiffStat(dir).Return("", gitErr).Once()
 			},
-			wantPR:  nil,
-			wantErr: nil,
+			wantErr: fmt.Errorf("failed to get changes for example: %w", gitErr),
 		},
 		{
-			name: "real mode - push branch fails",
+			name: "real mode - nothing to commit, no changes needed",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch fails
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(true).Once()
-				mockProcessResult.EXPECT().Error().Return(assert.AnError).Once()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				mockUpgrade()
+				s.mockGit.EXPECT().DiffStat(dir).Return("", nil).Once()
+				s.mockGit.EXPECT().Commit(dir, prTitle).Return(services.ErrNothingToCommit).Once()
 			},
-			wantPR:  nil,
-			wantErr: fmt.Errorf("failed to push upgrade branch for example: %w", assert.AnError),
 		},
 		{
-			name: "real mode - push branch output doesn't contain commit message",
+			name: "real mode - commit fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch succeeds but output doesn't contain commit message
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("pushed to remote").Twice()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
+				mockUpgrade()
+				s.mockGit.EXPECT().DiffStat(dir).Return("go.mod | 2 +-", nil).Once()
+				s.mockGit.EXPECT().Commit(dir, prTitle).Return(gitErr).Once()
+			},
+			wantErr: fmt.Errorf("failed to commit upgrade for example: %w", gitErr),
+		},
+		{
+			name: "real mode - push branch fails",
+			real: true,
+			setup: func() {
+				mockUpgrade()
+				s.mockGit.EXPECT().DiffStat(dir).Return("go.mod | 2 +-", nil).Once()
+				s.mockGit.EXPECT().Commit(dir, prTitle).Return(nil).Once()
+				s.mockGit.EXPECT().Push(dir, upgradeBranch, true).Return(gitErr).Once()
 			},
-			wantPR:  nil,
-			wantErr: errors.New("failed to push upgrade branch for example: pushed to remote"),
+			wantErr: fmt.Errorf("failed to push upgrade branch for example: %w", gitErr),
 		},
 		{
 			name: "real mode - get pull requests fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch succeeds
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("chore: Upgrade framework to v1.16.0 (auto)").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock get pull requests fails
+				mockPush()
 				s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
 					State: "open",
 				}).Return(nil, assert.AnError).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
 			},
-			wantPR:  nil,
 			wantErr: assert.AnError,
 		},
 		{
 			name: "real mode - existing PR found",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch succeeds
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("chore: Upgrade framework to v1.16.0 (auto)").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock get pull requests returns existing PR
+				mockPush()
 				existingPR := &github.PullRequest{
 					Title:   convert.Pointer(prTitle),
 					HTMLURL: convert.Pointer("https://github.com/goravel/example/pull/123"),
@@ -753,104 +1073,37 @@ go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.
 				s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
 					State: "open",
 				}).Return([]*github.PullRequest{existingPR}, nil).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
 			},
-			// Note: Due to variable shadowing bug in the actual implementation,
-			// the PR is found but not returned because the inner 'pr' variable
-			// shadows the outer one
 			wantPR: &github.PullRequest{
 				Title:   convert.Pointer(prTitle),
 				HTMLURL: convert.Pointer("https://github.com/goravel/example/pull/123"),
 				Number:  convert.Pointer(123),
 			},
-			wantErr: nil,
 		},
 		{
 			name: "real mode - create new PR fails",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch succeeds
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("chore: Upgrade framework to v1.16.0 (auto)").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock get pull requests returns no existing PR
+				mockPush()
 				s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
 					State: "open",
 				}).Return([]*github.PullRequest{}, nil).Once()
-
-				// Mock create pull request fails
 				s.mockGithub.EXPECT().CreatePullRequest(owner, repo, &github.NewPullRequest{
 					Title: convert.Pointer(prTitle),
 					Head:  convert.Pointer(upgradeBranch),
 					Base:  convert.Pointer("master"),
 				}).Return(nil, assert.AnError).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
 			},
-			wantPR:  nil,
 			wantErr: assert.AnError,
 		},
 		{
 			name: "real mode - successful PR creation",
 			real: true,
 			setup: func() {
-				s.mockContext.EXPECT().Spinner("Creating upgrade PR for example...", mock.AnythingOfType("console.SpinnerOption")).
-					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
-						return opts.Action()
-					}).Once()
-
-				// Mock successful clone and mod
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(`rm -rf example && git clone git@github.com:goravel/example.git &&
-cd example && git checkout master && git branch -D auto-upgrade/v1.16.0 2>/dev/null || true && git checkout -b auto-upgrade/v1.16.0 &&
-go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.4.0 && go mod tidy`).
-					Return(mockProcessResult).Once()
-
-				// Mock check status returns dirty working tree
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("modified: go.mod").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git status`).Return(mockProcessResult).Once()
-
-				// Mock push branch succeeds
-				mockProcessResult = mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				mockProcessResult.EXPECT().Output().Return("chore: Upgrade framework to v1.16.0 (auto)").Once()
-				s.mockProcess.EXPECT().Run(`cd example && git add . && git commit -m "chore: Upgrade framework to v1.16.0 (auto)" && git push origin auto-upgrade/v1.16.0 -f`).
-					Return(mockProcessResult).Once()
-
-				// Mock get pull requests returns no existing PR
+				mockPush()
 				s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
 					State: "open",
 				}).Return([]*github.PullRequest{}, nil).Once()
-
-				// Mock create pull request succeeds
 				newPR := &github.PullRequest{
 					Title:   convert.Pointer(prTitle),
 					HTMLURL: convert.Pointer("https://github.com/goravel/example/pull/456"),
@@ -861,60 +1114,268 @@ go get github.com/goravel/framework@v1.16.0 && go get github.com/goravel/gin@v1.
 					Head:  convert.Pointer(upgradeBranch),
 					Base:  convert.Pointer("master"),
 				}).Return(newPR, nil).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf example").Return(nil).Once()
 			},
 			wantPR: &github.PullRequest{
 				Title:   convert.Pointer(prTitle),
 				HTMLURL: convert.Pointer("https://github.com/goravel/example/pull/456"),
 				Number:  convert.Pointer(456),
 			},
-			wantErr: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			s.release.real = tt.real
+			s.release.plan = services.NewPlan()
 			tt.setup()
 
-			pr, err := s.release.createUpgradePR(repo, "master", frameworkTag, dependencies)
+			pr, err := s.release.createUpgradePR(dir, io.Discard, s.release.newUpgradeInformation(repo, "master", frameworkTag, dependencies, dependencyBranches))
 
 			s.Equal(tt.wantPR, pr)
+			s.Equal(tt.wantActions, s.release.plan.Actions)
 			s.Equal(tt.wantErr, err)
 		})
 	}
 }
 
+func (s *ReleaseTestSuite) Test_createUpgradePRs() {
+	upgrades := []*UpgradeInformation{
+		s.release.newUpgradeInformation("gin", "master", "v1.16.0", []string{"go get github.com/goravel/framework@v1.16.0"}, map[string]string{"framework": "master"}),
+		s.release.newUpgradeInformation("goravel-lite", "master", "v1.16.0", []string{"go get github.com/goravel/framework@v1.16.0"}, map[string]string{"framework": "master"}),
+	}
+
+	s.Run("reattach the recorded PR and skip the up to date repo", func() {
+		s.release.real = true
+		s.release.concurrency = 2
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.NoError(s.release.state.SetPR("gin", 1))
+		recordedPR := &github.PullRequest{
+			Number:  convert.Pointer(1),
+			State:   convert.Pointer("open"),
+			HTMLURL: convert.Pointer("https://github.com/goravel/gin/pull/1"),
+		}
+		s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(recordedPR, nil).Once()
+		s.mockContext.EXPECT().Spinner("Creating upgrade PRs for goravel-lite...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGit.EXPECT().Clone("git@github.com:goravel/goravel-lite.git", mock.AnythingOfType("string")).Return(nil).Once()
+		s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "master").Return(nil).Once()
+		s.mockGit.EXPECT().CreateBranch(mock.AnythingOfType("string"), "auto-upgrade/v1.16.0").Return(nil).Once()
+		mockProcessResult := mocksprocess.NewResult(s.T())
+		mockProcessResult.EXPECT().Failed().Return(false).Once()
+		s.expectRunInDir("go get github.com/goravel/framework@v1.16.0 && go mod tidy", mockProcessResult)
+		s.mockGit.EXPECT().DiffStat(mock.AnythingOfType("string")).Return("", nil).Once()
+		s.mockGit.EXPECT().Commit(mock.AnythingOfType("string"), "chore: Upgrade framework to v1.16.0 (auto)").Return(services.ErrNothingToCommit).Once()
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/goravel-lite", color.Green().Sprint("PASS")).Return().Once()
+
+		repoToPR, err := s.release.createUpgradePRs(upgrades)
+
+		s.NoError(err)
+		s.Equal(map[string]*github.PullRequest{
+			"gin":          recordedPR,
+			"goravel-lite": nil,
+		}, repoToPR)
+	})
+
+	s.Run("failed to create a PR", func() {
+		s.release.real = true
+		s.release.concurrency = 2
+		s.release.state = nil
+		s.mockContext.EXPECT().Spinner("Creating upgrade PRs for gin, goravel-lite...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+
+		for _, repo := range []string{"gin", "goravel-lite"} {
+			s.mockGit.EXPECT().Clone(fmt.Sprintf("git@github.com:goravel/%s.git", repo), mock.AnythingOfType("string")).Return(assert.AnError).Once()
+		}
+
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/gin", color.Red().Sprint("FAIL")).Return().Once()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/goravel-lite", color.Red().Sprint("FAIL")).Return().Once()
+
+		repoToPR, err := s.release.createUpgradePRs(upgrades)
+
+		s.Nil(repoToPR)
+		s.EqualError(err, fmt.Sprintf("failed to clone gin: %s\nfailed to clone goravel-lite: %s", assert.AnError, assert.AnError))
+	})
+}
+
+func (s *ReleaseTestSuite) Test_createVersionPR() {
+	var (
+		repo        = "framework"
+		prTitle     = "chore: Update version to v1.16.1 (auto)"
+		releaseInfo = &ReleaseInformation{
+			branch:     "v1.16.x",
+			currentTag: "v1.16.0",
+			repo:       repo,
+			tag:        "v1.16.1",
+		}
+	)
+
+	// Mock the repo is cloned into the dir that contains the version file
+	mockClone := func(dir string) {
+		s.NoError(os.MkdirAll(filepath.Join(dir, "support"), 0755))
+		s.NoError(os.WriteFile(filepath.Join(dir, "support", "constant.go"), []byte(`package support
+
+const Version string = "v1.16.0"
+
+const EnvFilePath = ".env"
+`), 0644))
+
+		s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", dir).Return(nil).Once()
+		s.mockGit.EXPECT().Checkout(dir, "v1.16.x").Return(nil).Once()
+		s.mockGit.EXPECT().CreateBranch(dir, "auto-version/v1.16.1").Return(nil).Once()
+	}
+
+	s.Run("happy path", func() {
+		dir := s.T().TempDir()
+		mockClone(dir)
+		s.mockGit.EXPECT().DiffStat(dir).Return("support/constant.go | 2 +-", nil).Once()
+		s.mockGit.EXPECT().Commit(dir, prTitle).Return(nil).Once()
+		s.mockGit.EXPECT().Push(dir, "auto-version/v1.16.1", true).Return(nil).Once()
+		s.mockGithub.EXPECT().GetPullRequests(owner, repo, &github.PullRequestListOptions{
+			State: "open",
+		}).Return(nil, nil).Once()
+		wantPR := &github.PullRequest{Number: convert.Pointer(1)}
+		s.mockGithub.EXPECT().CreatePullRequest(owner, repo, &github.NewPullRequest{
+			Title: convert.Pointer(prTitle),
+			Head:  convert.Pointer("auto-version/v1.16.1"),
+			Base:  convert.Pointer("v1.16.x"),
+		}).Return(wantPR, nil).Once()
+
+		pr, err := s.release.createVersionPR(dir, io.Discard, releaseInfo, "support/constant.go")
+
+		s.NoError(err)
+		s.Equal(wantPR, pr)
+
+		content, err := os.ReadFile(filepath.Join(dir, "support", "constant.go"))
+		s.NoError(err)
+		s.Equal(`package support
+
+const Version string = "v1.16.1"
+
+const EnvFilePath = ".env"
+`, string(content))
+	})
+
+	s.Run("already up to date", func() {
+		dir := s.T().TempDir()
+		mockClone(dir)
+		s.mockGit.EXPECT().DiffStat(dir).Return("", nil).Once()
+		s.mockGit.EXPECT().Commit(dir, prTitle).Return(services.ErrNothingToCommit).Once()
+
+		pr, err := s.release.createVersionPR(dir, io.Discard, releaseInfo, "support/constant.go")
+
+		s.NoError(err)
+		s.Nil(pr)
+	})
+
+	s.Run("version constant is missing", func() {
+		dir := s.T().TempDir()
+		mockClone(dir)
+		s.NoError(os.WriteFile(filepath.Join(dir, "support", "constant.go"), []byte("package support\n"), 0644))
+
+		pr, err := s.release.createVersionPR(dir, io.Discard, releaseInfo, "support/constant.go")
+
+		s.Nil(pr)
+		s.EqualError(err, "could not extract goravel/framework version from code")
+	})
+}
+
 func (s *ReleaseTestSuite) Test_getBranchFromTag() {
 	s.Run("happy path - branch doesn't exist", func() {
 		s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.16.x").Return(false, nil).Once()
-		branch := s.release.getBranchFromTag("framework", "v1.16.0")
+		branch, err := s.release.getBranchFromTag("framework", "v1.16.0")
+		s.NoError(err)
 		s.Equal("master", branch)
 	})
 
 	s.Run("happy path - branch exists", func() {
 		s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.16.x").Return(true, nil).Once()
-		branch := s.release.getBranchFromTag("framework", "v1.16.0")
+		branch, err := s.release.getBranchFromTag("framework", "v1.16.0")
+		s.NoError(err)
 		s.Equal("v1.16.x", branch)
 	})
 
+	s.Run("invalid tag", func() {
+		branch, err := s.release.getBranchFromTag("framework", "1.16.0")
+		s.EqualError(err, `invalid tag "1.16.0", it should start with v, e.g. v1.16.0`)
+		s.Equal(ExitCodeInvalidInput, ExitCode(err))
+		s.Empty(branch)
+	})
+
 	s.Run("failed to check branch exists", func() {
 		s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.16.x").Return(false, assert.AnError).Once()
-		s.Panics(func() {
-			_ = s.release.getBranchFromTag("framework", "v1.16.0")
-		})
+		branch, err := s.release.getBranchFromTag("framework", "v1.16.0")
+		s.ErrorIs(err, assert.AnError)
+		s.Empty(branch)
+	})
+}
+
+func (s *ReleaseTestSuite) Test_getDependencyGraph() {
+	goMods := map[string]string{
+		"framework":    "module github.com/goravel/framework",
+		"gin":          "module github.com/goravel/gin\n\nrequire github.com/goravel/framework v1.16.0",
+		"goravel-lite": "module goravel\n\nrequire (\n\tgithub.com/goravel/framework v1.16.0\n\tgithub.com/goravel/gin v1.4.0\n)",
+	}
+
+	s.Run("happy path", func() {
+		s.mockContext.EXPECT().Spinner("Building release dependency graph...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		for _, repo := range []string{"framework", "gin", "goravel-lite"} {
+			mockResponse := mocksclient.NewResponse(s.T())
+			mockResponse.EXPECT().Failed().Return(false).Once()
+			mockResponse.EXPECT().Body().Return(goMods[repo], nil).Once()
+			s.mockHttp.EXPECT().Get(fmt.Sprintf("https://raw.githubusercontent.com/goravel/%s/refs/heads/master/go.mod", repo)).
+				Return(mockResponse, nil).Once()
+		}
+
+		graph, err := s.release.getDependencyGraph("master")
+
+		s.NoError(err)
+		s.Equal([]string{"framework", "gin"}, graph.Dependencies("goravel-lite"))
+
+		levels, err := graph.Levels()
+		s.NoError(err)
+		s.Len(levels, 3)
+		s.Equal([]string{"goravel-lite"}, repoNames(levels[2]))
+	})
+
+	s.Run("failed to get go.mod", func() {
+		s.mockContext.EXPECT().Spinner("Building release dependency graph...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		mockResponse := mocksclient.NewResponse(s.T())
+		mockResponse.EXPECT().Failed().Return(true).Once()
+		mockResponse.EXPECT().Status().Return(404).Once()
+		s.mockHttp.EXPECT().Get("https://raw.githubusercontent.com/goravel/framework/refs/heads/master/go.mod").
+			Return(mockResponse, nil).Once()
+
+		graph, err := s.release.getDependencyGraph("master")
+
+		s.Nil(graph)
+		s.EqualError(err, "failed to get go.mod of goravel/framework on master: 404")
 	})
 }
 
 func (s *ReleaseTestSuite) Test_getPackagesReleaseInformation() {
 	tag := "v1.4.0"
 	branch := "v1.4.x"
-	packages = []string{
+	packages := []string{
 		"gin",
 		"installer",
 	}
+	s.release.manifest = newTestManifest(
+		&Repo{Name: "gin", Role: RolePackage, Dependencies: []string{"framework"}},
+		&Repo{Name: "installer", Role: RolePackage, Dependencies: []string{"framework"}, VersionFile: "support/constant.go"},
+		&Repo{Name: "framework", Role: RoleFramework, VersionFile: "support/constant.go"},
+	)
 
 	tests := []struct {
 		name    string
@@ -934,10 +1395,7 @@ func (s *ReleaseTestSuite) Test_getPackagesReleaseInformation() {
 						}).Once()
 
 					// Mock getLatestTag success
-					s.mockGithub.EXPECT().GetLatestRelease(owner, pkg, tag).Return(&github.RepositoryRelease{
-						TagName: convert.Pointer("v1.3.0"),
-						Name:    convert.Pointer(fmt.Sprintf("Release v1.3.0 for %s", pkg)),
-					}, nil).Once()
+					s.mockGithub.EXPECT().GetLatestRelease(owner, pkg, tag, false).Return(&services.LatestRelease{Tag: "v1.3.0"}, nil).Once()
 
 					if pkg == "framework" {
 						s.mockGithub.EXPECT().CheckBranchExists(owner, pkg, branch).Return(true, nil).Once()
@@ -964,6 +1422,14 @@ func (s *ReleaseTestSuite) Test_getPackagesReleaseInformation() {
 						}).Return(expectedNotes, nil).Once()
 					}
 
+					compareHead := "master"
+					if pkg == "framework" {
+						compareHead = "v1.4.x"
+					}
+					s.mockGithub.EXPECT().CompareCommits(owner, pkg, "v1.3.0", compareHead).Return([]*github.RepositoryCommit{
+						repositoryCommit(fmt.Sprintf("feat: Feature A for %s (#1)", pkg), "alice"),
+					}, nil).Once()
+
 					if pkg == "installer" {
 						mockResponse := mocksclient.NewResponse(s.T())
 						mockResponse.EXPECT().Body().Return(`package support
@@ -980,7 +1446,6 @@ const Version string = "v1.4.0"`, nil)
 
 const Version string = "v1.4.0"`, nil)
 
-						s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.4.x").Return(true, nil).Once()
 						s.mockHttp.EXPECT().Get("https://raw.githubusercontent.com/goravel/framework/refs/heads/v1.4.x/support/constant.go").
 							Return(mockResponse, nil).Once()
 					}
@@ -991,11 +1456,12 @@ const Version string = "v1.4.0"`, nil)
 				allPackages := append(packages, "framework")
 				for _, pkg := range allPackages {
 					releaseInformation := &ReleaseInformation{
+						branch:     "master",
 						currentTag: "",
 						latestTag:  "v1.3.0",
 						notes: &github.RepositoryReleaseNotes{
 							Name: fmt.Sprintf("Release v1.4.0 for %s", pkg),
-							Body: fmt.Sprintf("## What's Changed\n* Feature A for %s\n* Bug fix B for %s\n\n**Full Changelog**: https://github.com/goravel/%s/compare/v1.3.0...v1.4.0", pkg, pkg, pkg),
+							Body: fmt.Sprintf("## What's Changed\n\n### Features\n* feat: Feature A for %s by @alice in https://github.com/goravel/%s/pull/1\n\n**Full Changelog**: https://github.com/goravel/%s/compare/v1.3.0...v1.4.0", pkg, pkg, pkg),
 						},
 						repo: pkg,
 						tag:  "v1.4.0",
@@ -1004,6 +1470,9 @@ const Version string = "v1.4.0"`, nil)
 					if pkg == "installer" || pkg == "framework" {
 						releaseInformation.currentTag = "v1.4.0"
 					}
+					if pkg == "framework" {
+						releaseInformation.branch = branch
+					}
 
 					result[pkg] = releaseInformation
 				}
@@ -1021,7 +1490,7 @@ const Version string = "v1.4.0"`, nil)
 					}).Once()
 
 				// Mock getLatestTag fails for first package
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag).Return(nil, assert.AnError).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag, false).Return(nil, assert.AnError).Once()
 			},
 			want:    nil,
 			wantErr: assert.AnError,
@@ -1035,9 +1504,7 @@ const Version string = "v1.4.0"`, nil)
 						return opts.Action()
 					}).Once()
 
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag).Return(&github.RepositoryRelease{
-					TagName: convert.Pointer("v1.3.0"),
-				}, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag, false).Return(&services.LatestRelease{Tag: "v1.3.0"}, nil).Once()
 
 				s.mockGithub.EXPECT().CheckBranchExists(owner, "gin", branch).Return(false, nil).Once()
 
@@ -1049,6 +1516,7 @@ const Version string = "v1.4.0"`, nil)
 					Name: "Release v1.4.0 for gin",
 					Body: "## What's Changed\n* Feature A for gin",
 				}, nil).Once()
+				s.mockGithub.EXPECT().CompareCommits(owner, "gin", "v1.3.0", "master").Return(nil, nil).Once()
 
 				// Mock spinner for second package (installer) - this will fail
 				s.mockContext.EXPECT().Spinner("Getting installer release information for v1.4.0...", mock.AnythingOfType("console.SpinnerOption")).
@@ -1056,9 +1524,7 @@ const Version string = "v1.4.0"`, nil)
 						return opts.Action()
 					}).Once()
 
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "installer", tag).Return(&github.RepositoryRelease{
-					TagName: convert.Pointer("v1.3.0"),
-				}, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "installer", tag, false).Return(&services.LatestRelease{Tag: "v1.3.0"}, nil).Once()
 
 				s.mockGithub.EXPECT().CheckBranchExists(owner, "installer", branch).Return(false, nil).Once()
 
@@ -1089,7 +1555,7 @@ const Version string = "v1.4.0"`, nil)
 						return opts.Action()
 					}).Once()
 
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag).Return(nil, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag, false).Return(nil, nil).Once()
 
 				s.mockGithub.EXPECT().CheckBranchExists(owner, "gin", branch).Return(false, nil).Once()
 
@@ -1109,9 +1575,7 @@ const Version string = "v1.4.0"`, nil)
 						return opts.Action()
 					}).Once()
 
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "installer", tag).Return(&github.RepositoryRelease{
-					TagName: convert.Pointer("v1.3.0"),
-				}, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "installer", tag, false).Return(&services.LatestRelease{Tag: "v1.3.0"}, nil).Once()
 
 				s.mockGithub.EXPECT().CheckBranchExists(owner, "installer", branch).Return(false, nil).Once()
 
@@ -1123,6 +1587,9 @@ const Version string = "v1.4.0"`, nil)
 					Name: "Release v1.4.0 for installer",
 					Body: "## What's Changed\n* Feature A for installer",
 				}, nil).Once()
+				s.mockGithub.EXPECT().CompareCommits(owner, "installer", "v1.3.0", "master").Return([]*github.RepositoryCommit{
+					repositoryCommit("feat: Feature A for installer (#1)", "alice"),
+				}, nil).Once()
 
 				mockResponse := mocksclient.NewResponse(s.T())
 				mockResponse.EXPECT().Body().Return(`package support
@@ -1138,9 +1605,7 @@ const Version string = "v1.4.0"`, nil)
 						return opts.Action()
 					}).Once()
 
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", tag).Return(&github.RepositoryRelease{
-					TagName: convert.Pointer("v1.3.0"),
-				}, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", tag, false).Return(&services.LatestRelease{Tag: "v1.3.0"}, nil).Once()
 
 				s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", branch).Return(true, nil).Once()
 
@@ -1152,13 +1617,15 @@ const Version string = "v1.4.0"`, nil)
 					Name: "Release v1.4.0 for framework",
 					Body: "## What's Changed\n* Feature A for framework",
 				}, nil).Once()
+				s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.3.0", "v1.4.x").Return([]*github.RepositoryCommit{
+					repositoryCommit("feat: Feature A for framework (#1)", "alice"),
+				}, nil).Once()
 
 				mockResponse2 := mocksclient.NewResponse(s.T())
 				mockResponse2.EXPECT().Body().Return(`package support
 
 const Version string = "v1.4.0"`, nil)
 
-				s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.4.x").Return(true, nil).Once()
 				s.mockHttp.EXPECT().Get("https://raw.githubusercontent.com/goravel/framework/refs/heads/v1.4.x/support/constant.go").
 					Return(mockResponse2, nil).Once()
 			},
@@ -1166,6 +1633,7 @@ const Version string = "v1.4.0"`, nil)
 				result := make(map[string]*ReleaseInformation)
 				// First package with empty latestTag
 				result["gin"] = &ReleaseInformation{
+					branch:     "master",
 					currentTag: "",
 					latestTag:  "",
 					notes: &github.RepositoryReleaseNotes{
@@ -1177,22 +1645,24 @@ const Version string = "v1.4.0"`, nil)
 				}
 				// Second package
 				result["installer"] = &ReleaseInformation{
+					branch:     "master",
 					currentTag: "v1.4.0",
 					latestTag:  "v1.3.0",
 					notes: &github.RepositoryReleaseNotes{
 						Name: "Release v1.4.0 for installer",
-						Body: "## What's Changed\n* Feature A for installer",
+						Body: "## What's Changed\n\n### Features\n* feat: Feature A for installer by @alice in https://github.com/goravel/installer/pull/1\n\n**Full Changelog**: https://github.com/goravel/installer/compare/v1.3.0...v1.4.0",
 					},
 					repo: "installer",
 					tag:  "v1.4.0",
 				}
 				// Third package
 				result["framework"] = &ReleaseInformation{
+					branch:     branch,
 					currentTag: "v1.4.0",
 					latestTag:  "v1.3.0",
 					notes: &github.RepositoryReleaseNotes{
 						Name: "Release v1.4.0 for framework",
-						Body: "## What's Changed\n* Feature A for framework",
+						Body: "## What's Changed\n\n### Features\n* feat: Feature A for framework by @alice in https://github.com/goravel/framework/pull/1\n\n**Full Changelog**: https://github.com/goravel/framework/compare/v1.3.0...v1.4.0",
 					},
 					repo: "framework",
 					tag:  "v1.4.0",
@@ -1380,20 +1850,64 @@ func (s *ReleaseTestSuite) Test_generateReleaseNotes() {
 			setup: func() {
 				expectedNotes := &github.RepositoryReleaseNotes{
 					Name: "Release v1.16.0",
-					Body: "## What's Changed\n* Feature A\n* Bug fix B\n\n**Full Changelog**: https://github.com/goravel/framework/compare/v1.15.0...v1.16.0",
+					Body: "## What's Changed\n* Feature A by @alice in https://github.com/goravel/framework/pull/1\n\n## New Contributors\n* @alice made their first contribution in https://github.com/goravel/framework/pull/1\n\n**Full Changelog**: https://github.com/goravel/framework/compare/v1.15.0...v1.16.0",
 				}
 				s.mockGithub.EXPECT().GenerateReleaseNotes(owner, "framework", &github.GenerateNotesOptions{
 					TagName:         "v1.16.0",
 					PreviousTagName: convert.Pointer("v1.15.0"),
 					TargetCommitish: convert.Pointer(branch),
 				}).Return(expectedNotes, nil).Once()
+				s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.15.0", branch).Return([]*github.RepositoryCommit{
+					repositoryCommit("feat: Feature A (#1)", "alice"),
+					repositoryCommit("chore: Upgrade framework to v1.15.1 (auto) (#2)", "hwbrzzl"),
+					repositoryCommit("fix(deps): update module golang.org/x/mod to v0.33.0 (#3)", "renovate[bot]"),
+					repositoryCommit("Merge pull request #4 from bob/fix\n\nfix: Bug fix B", "bob"),
+				}, nil).Once()
 			},
 			wantNotes: &github.RepositoryReleaseNotes{
 				Name: "Release v1.16.0",
-				Body: "## What's Changed\n* Feature A\n* Bug fix B\n\n**Full Changelog**: https://github.com/goravel/framework/compare/v1.15.0...v1.16.0",
+				Body: "## What's Changed\n\n### Features\n* feat: Feature A by @alice in https://github.com/goravel/framework/pull/1\n\n### Fixes\n* fix: Bug fix B by @bob in https://github.com/goravel/framework/pull/4\n\n## New Contributors\n* @alice made their first contribution in https://github.com/goravel/framework/pull/1\n\n**Full Changelog**: https://github.com/goravel/framework/compare/v1.15.0...v1.16.0",
 			},
 			wantErr: nil,
 		},
+		{
+			name:            "first release, the notes generated by GitHub are kept",
+			repo:            "framework",
+			tagName:         "v1.16.0",
+			previousTagName: "",
+			setup: func() {
+				s.mockGithub.EXPECT().GenerateReleaseNotes(owner, "framework", &github.GenerateNotesOptions{
+					TagName:         "v1.16.0",
+					PreviousTagName: convert.Pointer(""),
+					TargetCommitish: convert.Pointer(branch),
+				}).Return(&github.RepositoryReleaseNotes{
+					Name: "Release v1.16.0",
+					Body: "## What's Changed\n* Feature A",
+				}, nil).Once()
+			},
+			wantNotes: &github.RepositoryReleaseNotes{
+				Name: "Release v1.16.0",
+				Body: "## What's Changed\n* Feature A",
+			},
+		},
+		{
+			name:            "failed to compare commits",
+			repo:            "framework",
+			tagName:         "v1.16.0",
+			previousTagName: "v1.15.0",
+			setup: func() {
+				s.mockGithub.EXPECT().GenerateReleaseNotes(owner, "framework", &github.GenerateNotesOptions{
+					TagName:         "v1.16.0",
+					PreviousTagName: convert.Pointer("v1.15.0"),
+					TargetCommitish: convert.Pointer(branch),
+				}).Return(&github.RepositoryReleaseNotes{
+					Name: "Release v1.16.0",
+				}, nil).Once()
+				s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.15.0", branch).Return(nil, assert.AnError).Once()
+			},
+			wantNotes: nil,
+			wantErr:   assert.AnError,
+		},
 		{
 			name:            "github API error",
 			repo:            "framework",
@@ -1439,73 +1953,42 @@ func (s *ReleaseTestSuite) Test_generateReleaseNotes() {
 }
 
 func (s *ReleaseTestSuite) Test_getLatestTag() {
-	tag := "v1.16.2"
+	tag := "v1.16.3"
 
 	tests := []struct {
-		name    string
-		repo    string
-		setup   func()
-		wantTag string
-		wantErr error
+		name          string
+		repo          string
+		setup         func()
+		wantTag       string
+		wantDecisions []string
+		wantErr       error
 	}{
 		{
 			name: "successful tag retrieval",
 			repo: "gin",
 			setup: func() {
-				mockRelease := &github.RepositoryRelease{
-					TagName: convert.Pointer("v1.16.0"),
-					Name:    convert.Pointer("Release v1.16.0"),
-					Body:    convert.Pointer("This is a test release"),
-				}
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag).Return(mockRelease, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag, false).Return(&services.LatestRelease{
+					Tag:       "v1.16.2",
+					Decisions: []string{"skipped the versions not lower than v1.16.3: v1.17.0"},
+				}, nil).Once()
 			},
-			wantTag: "v1.16.0",
-			wantErr: nil,
+			wantTag:       "v1.16.2",
+			wantDecisions: []string{"skipped the versions not lower than v1.16.3: v1.17.0"},
 		},
 		{
 			name: "github API error",
 			repo: "gin",
 			setup: func() {
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag).Return(nil, assert.AnError).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", tag, false).Return(nil, assert.AnError).Once()
 			},
-			wantTag: "",
 			wantErr: assert.AnError,
 		},
 		{
-			name: "github API returns nil release",
+			name: "never released",
 			repo: "fiber",
 			setup: func() {
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "fiber", tag).Return(nil, nil).Once()
+				s.mockGithub.EXPECT().GetLatestRelease(owner, "fiber", tag, false).Return(nil, nil).Once()
 			},
-			wantTag: "",
-		},
-		{
-			name: "github API returns release with nil TagName",
-			repo: "framework",
-			setup: func() {
-				mockRelease := &github.RepositoryRelease{
-					TagName: nil,
-					Name:    convert.Pointer("Release without tag"),
-					Body:    convert.Pointer("This release has no tag"),
-				}
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", tag).Return(mockRelease, nil).Once()
-			},
-			wantTag: "",
-			wantErr: fmt.Errorf("latest release tag name is nil for %s/framework", owner),
-		},
-		{
-			name: "github API returns release with empty TagName",
-			repo: "example",
-			setup: func() {
-				mockRelease := &github.RepositoryRelease{
-					TagName: convert.Pointer(""),
-					Name:    convert.Pointer("Release with empty tag"),
-					Body:    convert.Pointer("This release has empty tag"),
-				}
-				s.mockGithub.EXPECT().GetLatestRelease(owner, "example", tag).Return(mockRelease, nil).Once()
-			},
-			wantTag: "",
-			wantErr: nil,
 		},
 	}
 
@@ -1513,14 +1996,232 @@ func (s *ReleaseTestSuite) Test_getLatestTag() {
 		s.Run(tt.name, func() {
 			tt.setup()
 
-			tag, err := s.release.getLatestTag(tt.repo, tag)
+			latestTag, decisions, err := s.release.getLatestTag(tt.repo, tag)
 
-			s.Equal(tt.wantTag, tag)
+			s.Equal(tt.wantTag, latestTag)
+			s.Equal(tt.wantDecisions, decisions)
 			s.Equal(tt.wantErr, err)
 		})
 	}
 }
 
+func (s *ReleaseTestSuite) Test_getNextVersion() {
+	expectSpinner := func() {
+		s.mockContext.EXPECT().Spinner("Checking the PRs merged since the latest releases...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+	}
+	latest := &services.LatestRelease{Tag: "v1.16.1"}
+
+	s.Run("major - minor bump", func() {
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(latest, nil).Twice()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "gin", "", false).Return(latest, nil).Once()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "goravel-lite", "", false).Return(nil, nil).Once()
+		expectSpinner()
+		s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.1", "master").Return([]*github.RepositoryCommit{
+			repositoryCommit("feat: Add rate limiter (#1)", "hwbrzzl"),
+		}, nil).Once()
+		s.mockGithub.EXPECT().CompareCommits(owner, "gin", "v1.16.1", "master").Return([]*github.RepositoryCommit{
+			repositoryCommit("fix: Fix the context (#2)", "hwbrzzl"),
+		}, nil).Once()
+
+		version, bump, err := s.release.getNextVersion(false)
+
+		s.NoError(err)
+		s.Equal("v1.17.0", version.String())
+		s.Equal(BumpMinor, bump)
+	})
+
+	s.Run("patch", func() {
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(latest, nil).Twice()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "goravel-lite", "", false).Return(latest, nil).Once()
+		expectSpinner()
+		for _, repo := range []string{"framework", "goravel-lite"} {
+			s.mockGithub.EXPECT().CheckBranchExists(owner, repo, "v1.16.x").Return(true, nil).Once()
+			s.mockGithub.EXPECT().CompareCommits(owner, repo, "v1.16.1", "v1.16.x").Return([]*github.RepositoryCommit{
+				repositoryCommit("fix: Fix the session driver (#2)", "hwbrzzl"),
+			}, nil).Once()
+		}
+
+		version, bump, err := s.release.getNextVersion(true)
+
+		s.NoError(err)
+		s.Equal("v1.16.2", version.String())
+		s.Equal(BumpPatch, bump)
+	})
+
+	s.Run("patch - refused by a feature on the version branch", func() {
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(latest, nil).Twice()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "goravel-lite", "", false).Return(latest, nil).Once()
+		expectSpinner()
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.16.x").Return(true, nil).Once()
+		s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.1", "v1.16.x").Return([]*github.RepositoryCommit{
+			repositoryCommit("feat: Add rate limiter (#1)", "hwbrzzl"),
+		}, nil).Once()
+		s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel-lite", "v1.16.x").Return(false, nil).Once()
+		s.mockGithub.EXPECT().CompareCommits(owner, "goravel-lite", "v1.16.1", "master").Return(nil, nil).Once()
+
+		version, bump, err := s.release.getNextVersion(true)
+
+		s.EqualError(err, "a patch release is refused, feature or breaking PRs landed on the version branches of framework")
+		s.Nil(version)
+		s.Equal(BumpMinor, bump)
+	})
+
+	s.Run("failed to compare commits", func() {
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(latest, nil).Twice()
+		expectSpinner()
+		s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.1", "master").Return(nil, assert.AnError).Once()
+
+		_, _, err := s.release.getNextVersion(false)
+
+		s.ErrorIs(err, assert.AnError)
+	})
+
+	s.Run("framework has never been released", func() {
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(nil, nil).Once()
+
+		_, _, err := s.release.getNextVersion(false)
+
+		s.EqualError(err, "goravel/framework has never been released, the next version can't be suggested")
+	})
+}
+
+func (s *ReleaseTestSuite) Test_getPatchPlan() {
+	s.release.manifest = newTestManifest(
+		&Repo{Name: "framework", Role: RoleFramework, Branch: true},
+		&Repo{Name: "goravel-lite", Role: RoleApp, Dependencies: []string{"framework"}, SkipRelease: true},
+		&Repo{Name: "goravel", Role: RoleApp, AutoUpgrade: true},
+	)
+	notes := &github.RepositoryReleaseNotes{
+		Name: "v1.16.1",
+		Body: "## What's Changed",
+	}
+
+	s.mockContext.EXPECT().Spinner("Getting framework release information for v1.16.1...", mock.AnythingOfType("console.SpinnerOption")).
+		RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+			return opts.Action()
+		}).Once()
+	s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "v1.16.1", false).Return(&services.LatestRelease{Tag: "v1.16.0"}, nil).Once()
+	s.mockGithub.EXPECT().CheckBranchExists(owner, "framework", "v1.16.x").Return(true, nil).Once()
+	s.mockGithub.EXPECT().GenerateReleaseNotes(owner, "framework", &github.GenerateNotesOptions{
+		TagName:         "v1.16.1",
+		PreviousTagName: convert.Pointer("v1.16.0"),
+		TargetCommitish: convert.Pointer("v1.16.x"),
+	}).Return(notes, nil).Once()
+	s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.0", "v1.16.x").Return(nil, nil).Once()
+	s.mockGithub.EXPECT().CheckBranchExists(owner, "goravel-lite", "v1.16.x").Return(true, nil).Once()
+
+	plan, err := s.release.getPatchPlan("v1.16.1")
+
+	s.NoError(err)
+	s.Equal(&ReleasePlan{
+		Command: "patch",
+		Tag:     "v1.16.1",
+		Levels: [][]*ReleasePlanRepo{
+			{
+				{
+					Owner: owner,
+					Repo:  "framework",
+					Release: &ReleasePlanRelease{
+						Tag:         "v1.16.1",
+						PreviousTag: "v1.16.0",
+						Branch:      "v1.16.x",
+						Name:        notes.Name,
+						Body:        notes.Body,
+					},
+				},
+			},
+			{
+				{
+					Owner:       owner,
+					Repo:        "goravel-lite",
+					SkipRelease: true,
+					Upgrade: &ReleasePlanUpgrade{
+						Base:         "v1.16.x",
+						Head:         "auto-upgrade/v1.16.1",
+						Title:        "chore: Upgrade framework to v1.16.1 (auto)",
+						Commands:     []string{"go get github.com/goravel/framework@v1.16.1"},
+						Dependencies: map[string]string{"framework": "v1.16.x"},
+					},
+				},
+				{
+					Owner:       owner,
+					Repo:        "goravel",
+					AutoUpgrade: true,
+				},
+			},
+		},
+	}, plan)
+}
+
+func (s *ReleaseTestSuite) Test_getRecordedPR() {
+	repo := "gin"
+
+	s.Run("state is nil", func() {
+		s.release.state = nil
+
+		pr, err := s.release.getRecordedPR(repo)
+
+		s.Nil(pr)
+		s.NoError(err)
+	})
+
+	s.Run("no PR recorded", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+
+		pr, err := s.release.getRecordedPR(repo)
+
+		s.Nil(pr)
+		s.NoError(err)
+	})
+
+	s.Run("failed to get recorded PR", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.NoError(s.release.state.SetPR(repo, 1))
+		s.mockGithub.EXPECT().GetPullRequest(owner, repo, 1).Return(nil, assert.AnError).Once()
+
+		pr, err := s.release.getRecordedPR(repo)
+
+		s.Nil(pr)
+		s.Equal(assert.AnError, err)
+	})
+
+	s.Run("recorded PR is closed without merging", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.NoError(s.release.state.SetPR(repo, 1))
+		s.mockGithub.EXPECT().GetPullRequest(owner, repo, 1).Return(&github.PullRequest{
+			Number: convert.Pointer(1),
+			State:  convert.Pointer("closed"),
+			Merged: convert.Pointer(false),
+		}, nil).Once()
+
+		pr, err := s.release.getRecordedPR(repo)
+
+		s.Nil(pr)
+		s.NoError(err)
+	})
+
+	s.Run("reattach to open PR", func() {
+		recordedPR := &github.PullRequest{
+			Number:  convert.Pointer(1),
+			HTMLURL: convert.Pointer("https://github.com/goravel/gin/pull/1"),
+			State:   convert.Pointer("open"),
+		}
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.NoError(s.release.state.SetPR(repo, 1))
+		s.mockGithub.EXPECT().GetPullRequest(owner, repo, 1).Return(recordedPR, nil).Once()
+
+		pr, err := s.release.getRecordedPR(repo)
+
+		s.Equal(recordedPR, pr)
+		s.NoError(err)
+	})
+
+	s.release.state = nil
+}
+
 func (s *ReleaseTestSuite) Test_isReleaseExist() {
 	tests := []struct {
 		name    string
@@ -1535,24 +2236,10 @@ func (s *ReleaseTestSuite) Test_isReleaseExist() {
 			repo: "framework",
 			tag:  "v1.16.0",
 			setup: func() {
-				releases := []*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.15.0"),
-						Name:    convert.Pointer("Release v1.15.0"),
-					},
-					{
-						TagName: convert.Pointer("v1.16.0"),
-						Name:    convert.Pointer("Release v1.16.0"),
-					},
-					{
-						TagName: convert.Pointer("v1.14.0"),
-						Name:    convert.Pointer("Release v1.14.0"),
-					},
-				}
-				s.mockGithub.EXPECT().GetReleases(owner, "framework", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(releases, nil).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, "framework", "v1.16.0").Return(&github.RepositoryRelease{
+					TagName: convert.Pointer("v1.16.0"),
+					Name:    convert.Pointer("Release v1.16.0"),
+				}, nil).Once()
 			},
 			want:    true,
 			wantErr: nil,
@@ -1562,33 +2249,7 @@ func (s *ReleaseTestSuite) Test_isReleaseExist() {
 			repo: "gin",
 			tag:  "v1.5.0",
 			setup: func() {
-				releases := []*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.4.0"),
-						Name:    convert.Pointer("Release v1.4.0"),
-					},
-					{
-						TagName: convert.Pointer("v1.3.0"),
-						Name:    convert.Pointer("Release v1.3.0"),
-					},
-				}
-				s.mockGithub.EXPECT().GetReleases(owner, "gin", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(releases, nil).Once()
-			},
-			want:    false,
-			wantErr: nil,
-		},
-		{
-			name: "no releases found",
-			repo: "new-repo",
-			tag:  "v1.0.0",
-			setup: func() {
-				s.mockGithub.EXPECT().GetReleases(owner, "new-repo", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return([]*github.RepositoryRelease{}, nil).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, "gin", "v1.5.0").Return(nil, nil).Once()
 			},
 			want:    false,
 			wantErr: nil,
@@ -1598,66 +2259,189 @@ func (s *ReleaseTestSuite) Test_isReleaseExist() {
 			repo: "framework",
 			tag:  "v1.16.0",
 			setup: func() {
-				s.mockGithub.EXPECT().GetReleases(owner, "framework", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(nil, assert.AnError).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, "framework", "v1.16.0").Return(nil, assert.AnError).Once()
 			},
 			want:    false,
 			wantErr: assert.AnError,
 		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setup()
+
+			result, err := s.release.isReleaseExist(tt.repo, tt.tag)
+
+			s.Equal(tt.want, result)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ReleaseTestSuite) Test_mergePRs() {
+	pr := func(repo string, number int) *github.PullRequest {
+		return &github.PullRequest{
+			Number:    convert.Pointer(number),
+			HTMLURL:   convert.Pointer(fmt.Sprintf("https://github.com/goravel/%s/pull/%d", repo, number)),
+			State:     convert.Pointer("open"),
+			Merged:    convert.Pointer(false),
+			Head:      &github.PullRequestBranch{Ref: convert.Pointer("auto-upgrade/v1.17.0")},
+			Base:      &github.PullRequestBranch{Ref: convert.Pointer("master")},
+			CreatedAt: &github.Timestamp{Time: time.Now()},
+		}
+	}
+	checkRuns := func(status, conclusion string) []*github.CheckRun {
+		return []*github.CheckRun{{Name: convert.Pointer("test"), Status: convert.Pointer(status), Conclusion: convert.Pointer(conclusion)}}
+	}
+	expectChecks := func(repo string, checkRuns []*github.CheckRun) {
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, repo, "master").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, repo, "auto-upgrade/v1.17.0").Return(&github.CombinedStatus{}, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, repo, "auto-upgrade/v1.17.0").Return(checkRuns, nil).Once()
+	}
+	expectSummary := func(results map[string]string) {
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		for repo, status := range results {
+			s.mockContext.EXPECT().TwoColumnDetail("goravel/"+repo, status).Return().Once()
+		}
+	}
+
+	tests := []struct {
+		name        string
+		real        bool
+		manual      bool
+		mergeMethod string
+		repoToPR    map[string]*github.PullRequest
+		setup       func()
+		wantErr     string
+	}{
+		{
+			name:        "invalid merge method",
+			real:        true,
+			mergeMethod: "fast-forward",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1)},
+			setup:       func() {},
+			wantErr:     "invalid merge method fast-forward, it should be one of merge, squash, rebase",
+		},
 		{
-			name: "release with nil TagName",
-			repo: "fiber",
-			tag:  "v1.0.0",
+			name:        "the planned PRs are considered merged in preview mode",
+			mergeMethod: "squash",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1), "fiber": nil},
+			setup:       func() {},
+		},
+		{
+			name:        "merge once the checks pass",
+			real:        true,
+			mergeMethod: "rebase",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1), "fiber": nil},
 			setup: func() {
-				releases := []*github.RepositoryRelease{
-					{
-						TagName: nil,
-						Name:    convert.Pointer("Release without tag"),
-					},
-					{
-						TagName: convert.Pointer("v1.0.0"),
-						Name:    convert.Pointer("Release v1.0.0"),
-					},
-				}
-				s.mockGithub.EXPECT().GetReleases(owner, "fiber", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(releases, nil).Once()
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(pr("gin", 1), nil).Times(3)
+				// No check is reported right after the PR is created
+				expectChecks("gin", nil)
+				expectChecks("gin", checkRuns("in_progress", ""))
+				expectChecks("gin", checkRuns("completed", "success"))
+				s.mockGithub.EXPECT().MergePullRequest(owner, "gin", 1, "rebase").Return(nil).Once()
+				expectSummary(map[string]string{"gin": color.Green().Sprint("PASS")})
 			},
-			want:    true,
-			wantErr: nil,
 		},
 		{
-			name: "case sensitive tag matching",
-			repo: "example",
-			tag:  "V1.0.0",
+			name:        "merge without any check after the grace period",
+			real:        true,
+			mergeMethod: "squash",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1)},
 			setup: func() {
-				releases := []*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.0.0"),
-						Name:    convert.Pointer("Release v1.0.0"),
-					},
-				}
-				s.mockGithub.EXPECT().GetReleases(owner, "example", &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(releases, nil).Once()
+				created := pr("gin", 1)
+				created.CreatedAt = &github.Timestamp{Time: time.Now().Add(-noChecksGracePeriod)}
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(created, nil).Once()
+				expectChecks("gin", nil)
+				s.mockGithub.EXPECT().MergePullRequest(owner, "gin", 1, "squash").Return(nil).Once()
+				expectSummary(map[string]string{"gin": color.Green().Sprint("PASS")})
 			},
-			want:    false,
-			wantErr: nil,
+		},
+		{
+			name:        "wait for a maintainer to merge without auto merge",
+			real:        true,
+			manual:      true,
+			mergeMethod: "squash",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1)},
+			setup: func() {
+				merged := pr("gin", 1)
+				merged.Merged = convert.Pointer(true)
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(pr("gin", 1), nil).Once()
+				expectChecks("gin", checkRuns("completed", "success"))
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(merged, nil).Once()
+				expectSummary(map[string]string{"gin": color.Green().Sprint("PASS")})
+			},
+		},
+		{
+			name:        "report the failures of every repo",
+			real:        true,
+			mergeMethod: "squash",
+			repoToPR: map[string]*github.PullRequest{
+				"fiber": pr("fiber", 1),
+				"gin":   pr("gin", 2),
+				"s3":    pr("s3", 3),
+				"redis": pr("redis", 4),
+			},
+			setup: func() {
+				merged := pr("fiber", 1)
+				merged.Merged = convert.Pointer(true)
+				s.mockGithub.EXPECT().GetPullRequest(owner, "fiber", 1).Return(merged, nil).Once()
+
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 2).Return(pr("gin", 2), nil).Once()
+				expectChecks("gin", checkRuns("completed", "failure"))
+
+				closed := pr("s3", 3)
+				closed.State = convert.Pointer("closed")
+				s.mockGithub.EXPECT().GetPullRequest(owner, "s3", 3).Return(closed, nil).Once()
+
+				s.mockGithub.EXPECT().GetPullRequest(owner, "redis", 4).Return(pr("redis", 4), nil).Once()
+				expectChecks("redis", checkRuns("completed", "success"))
+				s.mockGithub.EXPECT().MergePullRequest(owner, "redis", 4, "squash").Return(assert.AnError).Once()
+
+				expectSummary(map[string]string{
+					"fiber": color.Green().Sprint("PASS"),
+					"gin":   color.Red().Sprint("FAIL"),
+					"redis": color.Red().Sprint("FAIL"),
+					"s3":    color.Red().Sprint("FAIL"),
+				})
+			},
+			wantErr: "goravel/gin: the checks of https://github.com/goravel/gin/pull/2 are failing: test\n" +
+				"goravel/redis: " + assert.AnError.Error() + "\n" +
+				"goravel/s3: https://github.com/goravel/s3/pull/3 is closed without being merged",
+		},
+		{
+			name:        "time out",
+			real:        true,
+			mergeMethod: "squash",
+			repoToPR:    map[string]*github.PullRequest{"gin": pr("gin", 1)},
+			setup: func() {
+				s.release.mergeTimeout = 5 * time.Millisecond
+				s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 1).Return(pr("gin", 1), nil)
+				s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "gin", "master").Return(nil, nil)
+				s.mockGithub.EXPECT().GetCombinedStatus(owner, "gin", "auto-upgrade/v1.17.0").Return(&github.CombinedStatus{}, nil)
+				s.mockGithub.EXPECT().GetCheckRuns(owner, "gin", "auto-upgrade/v1.17.0").Return(checkRuns("queued", ""), nil)
+				expectSummary(map[string]string{"gin": color.Red().Sprint("FAIL")})
+			},
+			wantErr: "goravel/gin: https://github.com/goravel/gin/pull/1 is not merged after 5ms",
 		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.release.real = tt.real
+			s.release.autoMerge = !tt.manual
+			s.release.mergeMethod = tt.mergeMethod
+			s.release.mergeTimeout = time.Minute
+			s.release.pollInterval = time.Millisecond
 			tt.setup()
 
-			result, err := s.release.isReleaseExist(tt.repo, tt.tag)
-
-			s.Equal(tt.want, result)
-			s.Equal(tt.wantErr, err)
+			err := s.release.mergePRs(tt.repoToPR)
+			if tt.wantErr != "" {
+				s.EqualError(err, tt.wantErr)
+			} else {
+				s.NoError(err)
+			}
 		})
 	}
 }
@@ -1666,25 +2450,35 @@ func (s *ReleaseTestSuite) Test_pushBranch() {
 	var (
 		repo   = "framework"
 		branch = "v1.16.x"
+		gitErr = &services.GitError{Command: "push", Err: assert.AnError}
 	)
 
 	tests := []struct {
-		name    string
-		real    bool
-		setup   func()
-		wantErr error
+		name        string
+		real        bool
+		setup       func()
+		wantActions []*services.PlanAction
+		wantErr     error
 	}{
 		{
-			name: "preview mode - skips push",
+			name: "preview mode - records the push in the plan",
 			real: false,
 			setup: func() {
 				s.mockContext.EXPECT().Spinner("Pushing branch v1.16.x for framework...", mock.AnythingOfType("console.SpinnerOption")).
 					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
 						return opts.Action()
 					}).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf framework").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(mock.AnythingOfType("string"), branch).Return(nil).Once()
+			},
+			wantActions: []*services.PlanAction{
+				{
+					Type:    services.PlanActionPushBranch,
+					Owner:   owner,
+					Repo:    repo,
+					Payload: &services.PushBranchPayload{Branch: branch, Base: "master", Force: true},
+				},
 			},
 			wantErr: nil,
 		},
@@ -1694,33 +2488,35 @@ func (s *ReleaseTestSuite) Test_pushBranch() {
 			setup: func() {
 				s.mockContext.EXPECT().Spinner("Pushing branch v1.16.x for framework...", mock.AnythingOfType("console.SpinnerOption")).
 					Return(assert.AnError).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf framework").Return(nil).Once()
 			},
 			wantErr: assert.AnError,
 		},
 		{
-			name: "real mode - command execution fails",
+			name: "real mode - clone fails",
 			real: true,
 			setup: func() {
 				s.mockContext.EXPECT().Spinner("Pushing branch v1.16.x for framework...", mock.AnythingOfType("console.SpinnerOption")).
 					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
 						return opts.Action()
 					}).Once()
-
-				// Mock the git command execution fails
-				expectedCommand := `rm -rf framework && git clone git@github.com:goravel/framework.git && 
-cd framework && git checkout master && git branch -D v1.16.x 2>/dev/null || true && git checkout -b v1.16.x && git push origin v1.16.x -f`
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(true).Once()
-				mockProcessResult.EXPECT().Error().Return(assert.AnError).Once()
-				s.mockProcess.EXPECT().Run(expectedCommand).Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf framework").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(gitErr).Once()
 			},
-			wantErr: fmt.Errorf("failed to push upgrade branch for framework: %w", assert.AnError),
+			wantErr: fmt.Errorf("failed to clone framework: %w", gitErr),
+		},
+		{
+			name: "real mode - push fails",
+			real: true,
+			setup: func() {
+				s.mockContext.EXPECT().Spinner("Pushing branch v1.16.x for framework...", mock.AnythingOfType("console.SpinnerOption")).
+					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+						return opts.Action()
+					}).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(mock.AnythingOfType("string"), branch).Return(nil).Once()
+				s.mockGit.EXPECT().Push(mock.AnythingOfType("string"), branch, true).Return(gitErr).Once()
+			},
+			wantErr: fmt.Errorf("failed to push branch v1.16.x for framework: %w", gitErr),
 		},
 		{
 			name: "real mode - successful push",
@@ -1730,16 +2526,10 @@ cd framework && git checkout master && git branch -D v1.16.x 2>/dev/null || true
 					RunAndReturn(func(msg string, opts console.SpinnerOption) error {
 						return opts.Action()
 					}).Once()
-
-				// Mock the git command execution succeeds with output containing branch name
-				expectedCommand := `rm -rf framework && git clone git@github.com:goravel/framework.git && 
-cd framework && git checkout master && git branch -D v1.16.x 2>/dev/null || true && git checkout -b v1.16.x && git push origin v1.16.x -f`
-				mockProcessResult := mocksprocess.NewResult(s.T())
-				mockProcessResult.EXPECT().Failed().Return(false).Once()
-				s.mockProcess.EXPECT().Run(expectedCommand).Return(mockProcessResult).Once()
-
-				// Mock the cleanup call in defer
-				s.mockProcess.EXPECT().Run("rm -rf framework").Return(nil).Once()
+				s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).Return(nil).Once()
+				s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "master").Return(nil).Once()
+				s.mockGit.EXPECT().CreateBranch(mock.AnythingOfType("string"), branch).Return(nil).Once()
+				s.mockGit.EXPECT().Push(mock.AnythingOfType("string"), branch, true).Return(nil).Once()
 			},
 			wantErr: nil,
 		},
@@ -1748,25 +2538,34 @@ cd framework && git checkout master && git branch -D v1.16.x 2>/dev/null || true
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			s.release.real = tt.real
+			s.release.plan = services.NewPlan()
 			tt.setup()
 
 			err := s.release.pushBranch(repo, branch)
 
+			s.Equal(tt.wantActions, s.release.plan.Actions)
 			s.Equal(tt.wantErr, err)
 		})
 	}
 }
 
 func (s *ReleaseTestSuite) Test_releaseRepo() {
+	expectChecks := func(checkRuns ...*github.CheckRun) {
+		s.mockContext.EXPECT().OptionInt("wait-checks").Return(0).Once()
+		s.mockGithub.EXPECT().GetRequiredStatusChecks(owner, "framework", "master").Return(nil, nil).Once()
+		s.mockGithub.EXPECT().GetCombinedStatus(owner, "framework", "master").Return(&github.CombinedStatus{}, nil).Once()
+		s.mockGithub.EXPECT().GetCheckRuns(owner, "framework", "master").Return(checkRuns, nil).Once()
+	}
+
 	var (
-		repo   = "framework"
-		tag    = "v1.16.0"
-		branch = "v1.16.x"
-		notes  = &github.RepositoryReleaseNotes{
+		repo  = "framework"
+		tag   = "v1.16.0"
+		notes = &github.RepositoryReleaseNotes{
 			Name: "Release v1.16.0",
 			Body: "## What's Changed\n* Feature A\n* Bug fix B",
 		}
 		releaseInfo = &ReleaseInformation{
+			branch:     "master",
 			currentTag: "v1.16.0",
 			latestTag:  "v1.15.0",
 			notes:      notes,
@@ -1785,18 +2584,9 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 			name:        "release already exists",
 			releaseInfo: releaseInfo,
 			setup: func() {
-				s.mockGithub.EXPECT().GetReleases(owner, repo, &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return([]*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.15.0"),
-						Name:    convert.Pointer("Release v1.15.0"),
-					},
-					{
-						TagName: convert.Pointer("v1.16.0"),
-						Name:    convert.Pointer("Release v1.16.0"),
-					},
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, repo, tag).Return(&github.RepositoryRelease{
+					TagName: convert.Pointer(tag),
+					Name:    convert.Pointer("Release v1.16.0"),
 				}, nil).Once()
 			},
 			wantErr: nil,
@@ -1806,17 +2596,8 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 			releaseInfo: releaseInfo,
 			setup: func() {
 				// Mock isReleaseExist returns false
-				s.mockGithub.EXPECT().GetReleases(owner, repo, &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return([]*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.15.0"),
-						Name:    convert.Pointer("Release v1.15.0"),
-					},
-				}, nil).Once()
-
-				s.mockGithub.EXPECT().CheckBranchExists(owner, repo, branch).Return(false, nil).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, repo, tag).Return(nil, nil).Once()
+				expectChecks(&github.CheckRun{Name: convert.Pointer("test"), Status: convert.Pointer("completed"), Conclusion: convert.Pointer("success")})
 
 				// Mock createRelease succeeds
 				s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
@@ -1832,10 +2613,7 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 			name:        "isReleaseExist fails",
 			releaseInfo: releaseInfo,
 			setup: func() {
-				s.mockGithub.EXPECT().GetReleases(owner, repo, &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return(nil, assert.AnError).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, repo, tag).Return(nil, assert.AnError).Once()
 			},
 			wantErr: assert.AnError,
 		},
@@ -1844,17 +2622,8 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 			releaseInfo: releaseInfo,
 			setup: func() {
 				// Mock isReleaseExist returns false
-				s.mockGithub.EXPECT().GetReleases(owner, repo, &github.ListOptions{
-					Page:    1,
-					PerPage: 10,
-				}).Return([]*github.RepositoryRelease{
-					{
-						TagName: convert.Pointer("v1.15.0"),
-						Name:    convert.Pointer("Release v1.15.0"),
-					},
-				}, nil).Once()
-
-				s.mockGithub.EXPECT().CheckBranchExists(owner, repo, branch).Return(false, nil).Once()
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, repo, tag).Return(nil, nil).Once()
+				expectChecks()
 
 				// Mock createRelease fails
 				s.mockGithub.EXPECT().CreateRelease(owner, repo, &github.RepositoryRelease{
@@ -1866,6 +2635,15 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 			},
 			wantErr: fmt.Errorf("failed to create release: %w", assert.AnError),
 		},
+		{
+			name:        "failing check blocks the release",
+			releaseInfo: releaseInfo,
+			setup: func() {
+				s.mockGithub.EXPECT().GetReleaseByTag(owner, repo, tag).Return(nil, nil).Once()
+				expectChecks(&github.CheckRun{Name: convert.Pointer("test"), Status: convert.Pointer("completed"), Conclusion: convert.Pointer("failure")})
+			},
+			wantErr: withExitCode(ExitCodeChecksFailed, errors.New("the checks of goravel/framework at master are failing: test")),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1878,3 +2656,437 @@ func (s *ReleaseTestSuite) Test_releaseRepo() {
 		})
 	}
 }
+
+func (s *ReleaseTestSuite) Test_resolveVersion() {
+	s.Run("explicit tag", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("v1.16.2").Once()
+
+		version, err := s.release.resolveVersion(true)
+
+		s.NoError(err)
+		s.Equal("v1.16.2", version.String())
+	})
+
+	s.Run("auto - major refused if only fixes are merged", func() {
+		s.release.manifest = newTestManifest(&Repo{Name: "framework", Role: RoleFramework})
+		s.mockContext.EXPECT().ArgumentString("tag").Return("auto").Once()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(&services.LatestRelease{Tag: "v1.16.1"}, nil).Twice()
+		s.mockContext.EXPECT().Spinner(mock.Anything, mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.1", "master").Return([]*github.RepositoryCommit{
+			repositoryCommit("fix: Fix the session driver (#2)", "hwbrzzl"),
+		}, nil).Once()
+
+		version, err := s.release.resolveVersion(false)
+
+		s.EqualError(err, "no feature or breaking PR is merged into master since the latest release, release a patch via the patch command")
+		s.Nil(version)
+	})
+
+	s.Run("auto - confirmed", func() {
+		s.release.manifest = newTestManifest(&Repo{Name: "framework", Role: RoleFramework})
+		s.mockContext.EXPECT().ArgumentString("tag").Return("auto").Once()
+		s.mockGithub.EXPECT().GetLatestRelease(owner, "framework", "", false).Return(&services.LatestRelease{Tag: "v1.16.1"}, nil).Twice()
+		s.mockContext.EXPECT().Spinner(mock.Anything, mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGithub.EXPECT().CompareCommits(owner, "framework", "v1.16.1", "master").Return([]*github.RepositoryCommit{
+			repositoryCommit("feat: Add rate limiter (#1)", "hwbrzzl"),
+		}, nil).Once()
+		s.mockContext.EXPECT().Confirm("Release the suggested version v1.17.0?").Return(true).Once()
+
+		version, err := s.release.resolveVersion(false)
+
+		s.NoError(err)
+		s.Equal("v1.17.0", version.String())
+	})
+}
+
+func (s *ReleaseTestSuite) Test_rollbackState() {
+	created := func() []*CreatedResource {
+		return []*CreatedResource{
+			{Kind: CreatedPullRequest, Repo: "gin", Name: "auto-upgrade/v1.16.0", ID: 3},
+			{Kind: CreatedRelease, Repo: "framework", Name: "v1.16.0", ID: 9},
+			{Kind: CreatedBranch, Repo: "framework", Name: "v1.16.x"},
+			{Kind: CreatedDefaultBranch, Repo: "framework", Name: "v1.16.x", Previous: "master"},
+		}
+	}
+	newState := func() *ReleaseState {
+		state := NewReleaseState(filepath.Join(s.T().TempDir(), "major-v1.16.0.json"), "major", "v1.16.0")
+		state.Created = created()
+		s.NoError(state.Save())
+
+		return state
+	}
+
+	s.Run("everything is undone", func() {
+		state := newState()
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "framework").Return("v1.16.x", nil).Once()
+		s.mockContext.EXPECT().Confirm("Reset the default branch of goravel/framework from v1.16.x to master?").Return(true).Once()
+		s.mockGithub.EXPECT().SetDefaultBranch(owner, "framework", "master").Return(nil).Once()
+		s.mockContext.EXPECT().Confirm("Delete branch v1.16.x of goravel/framework?").Return(true).Once()
+		s.mockGithub.EXPECT().DeleteBranch(owner, "framework", "v1.16.x").Return(nil).Once()
+		s.mockContext.EXPECT().Confirm("Delete release v1.16.0 of goravel/framework?").Return(true).Once()
+		s.mockGithub.EXPECT().DeleteRelease(owner, "framework", int64(9)).Return(nil).Once()
+		s.mockContext.EXPECT().Confirm("Delete tag v1.16.0 of goravel/framework?").Return(true).Once()
+		s.mockGithub.EXPECT().DeleteTag(owner, "framework", "v1.16.0").Return(nil).Once()
+		s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 3).Return(&github.PullRequest{State: convert.Pointer("open")}, nil).Once()
+		s.mockContext.EXPECT().Confirm("Close pull request #3 auto-upgrade/v1.16.0 of goravel/gin?").Return(true).Once()
+		s.mockGithub.EXPECT().ClosePullRequest(owner, "gin", 3).Return(nil).Once()
+
+		kept, err := s.release.rollbackState(state)
+		s.NoError(err)
+		s.Zero(kept)
+		s.NoFileExists(state.Path())
+	})
+
+	s.Run("declined steps and the changes since the release are kept", func() {
+		state := newState()
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "framework").Return("v1.17.x", nil).Once()
+		s.mockContext.EXPECT().Confirm("Delete branch v1.16.x of goravel/framework?").Return(false).Once()
+		s.mockContext.EXPECT().Confirm("Delete release v1.16.0 of goravel/framework?").Return(true).Once()
+		s.mockGithub.EXPECT().DeleteRelease(owner, "framework", int64(9)).Return(nil).Once()
+		s.mockContext.EXPECT().Confirm("Delete tag v1.16.0 of goravel/framework?").Return(false).Once()
+		s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 3).Return(&github.PullRequest{State: convert.Pointer("closed")}, nil).Once()
+
+		kept, err := s.release.rollbackState(state)
+		s.NoError(err)
+		s.Equal(2, kept)
+
+		loaded, err := LoadReleaseState(state.Path())
+		s.NoError(err)
+		s.Equal([]*CreatedResource{
+			{Kind: CreatedRelease, Repo: "framework", Name: "v1.16.0"},
+			{Kind: CreatedBranch, Repo: "framework", Name: "v1.16.x"},
+		}, loaded.Created)
+	})
+
+	s.Run("stops at the first failure", func() {
+		state := newState()
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "framework").Return("v1.16.x", nil).Once()
+		s.mockContext.EXPECT().Confirm("Reset the default branch of goravel/framework from v1.16.x to master?").Return(true).Once()
+		s.mockGithub.EXPECT().SetDefaultBranch(owner, "framework", "master").Return(assert.AnError).Once()
+
+		kept, err := s.release.rollbackState(state)
+		s.ErrorIs(err, assert.AnError)
+		s.Equal(4, kept)
+
+		loaded, err := LoadReleaseState(state.Path())
+		s.NoError(err)
+		s.Equal(created(), loaded.Created)
+	})
+
+	s.Run("non-interactive mode keeps everything", func() {
+		s.release.nonInteractive = true
+		state := newState()
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "framework").Return("v1.16.x", nil).Once()
+		s.mockGithub.EXPECT().GetPullRequest(owner, "gin", 3).Return(&github.PullRequest{State: convert.Pointer("open")}, nil).Once()
+
+		kept, err := s.release.rollbackState(state)
+		s.NoError(err)
+		s.Equal(4, kept)
+	})
+
+	s.release.nonInteractive = false
+}
+
+func (s *ReleaseTestSuite) Test_savePlan() {
+	s.Run("real mode - no plan", func() {
+		s.release.plan = nil
+
+		s.NoError(s.release.savePlan("major", "v1.16.0"))
+	})
+
+	s.Run("preview mode - prints and saves the plan", func() {
+		path := filepath.Join(s.T().TempDir(), "plan.json")
+		s.release.plan = services.NewPlan()
+		s.release.plan.Add(services.PlanActionCreateRelease, owner, "framework", &github.RepositoryRelease{
+			TagName:         convert.Pointer("v1.16.0"),
+			TargetCommitish: convert.Pointer("v1.16.x"),
+			Name:            convert.Pointer("v1.16.0"),
+			Body:            convert.Pointer("notes"),
+		})
+		s.release.plan.Add(services.PlanActionSetDefaultBranch, owner, "goravel-lite", &services.SetDefaultBranchPayload{Branch: "v1.16.x"})
+
+		s.mockContext.EXPECT().Option("plan").Return(path).Once()
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("1. goravel/framework", "create_release").Return().Once()
+		s.mockContext.EXPECT().TwoColumnDetail("2. goravel/goravel-lite", "set_default_branch").Return().Once()
+
+		s.NoError(s.release.savePlan("major", "v1.16.0"))
+
+		content, err := os.ReadFile(path)
+		s.NoError(err)
+		s.JSONEq(`{
+  "actions": [
+    {
+      "type": "create_release",
+      "owner": "goravel",
+      "repo": "framework",
+      "payload": {"tag_name": "v1.16.0", "target_commitish": "v1.16.x", "name": "v1.16.0", "body": "notes"}
+    },
+    {
+      "type": "set_default_branch",
+      "owner": "goravel",
+      "repo": "goravel-lite",
+      "payload": {"branch": "v1.16.x"}
+    }
+  ]
+}`, string(content))
+	})
+}
+
+func (s *ReleaseTestSuite) Test_setDefaultBranch() {
+	s.Run("not recorded without state", func() {
+		s.release.state = nil
+		s.mockGithub.EXPECT().SetDefaultBranch(owner, "goravel-lite", "v1.16.x").Return(nil).Once()
+
+		s.NoError(s.release.doSetDefaultBranch("goravel-lite", "v1.16.x"))
+	})
+
+	s.Run("recorded with the previous default branch", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "goravel-lite").Return("master", nil).Once()
+		s.mockGithub.EXPECT().SetDefaultBranch(owner, "goravel-lite", "v1.16.x").Return(nil).Once()
+
+		s.NoError(s.release.doSetDefaultBranch("goravel-lite", "v1.16.x"))
+		s.Equal([]*CreatedResource{{Kind: CreatedDefaultBranch, Repo: "goravel-lite", Name: "v1.16.x", Previous: "master"}}, s.release.state.Created)
+	})
+
+	s.Run("not recorded if unchanged", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+		s.mockGithub.EXPECT().GetDefaultBranch(owner, "goravel-lite").Return("v1.16.x", nil).Once()
+		s.mockGithub.EXPECT().SetDefaultBranch(owner, "goravel-lite", "v1.16.x").Return(nil).Once()
+
+		s.NoError(s.release.doSetDefaultBranch("goravel-lite", "v1.16.x"))
+		s.Empty(s.release.state.Created)
+	})
+
+	s.release.state = nil
+}
+
+func (s *ReleaseTestSuite) Test_step() {
+	s.Run("state is nil", func() {
+		s.release.state = nil
+
+		var called bool
+		err := s.release.step("test", func() error {
+			called = true
+			return nil
+		})
+
+		s.NoError(err)
+		s.True(called)
+	})
+
+	s.Run("action fails", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+
+		err := s.release.step("test", func() error {
+			return assert.AnError
+		})
+
+		s.Equal(assert.AnError, err)
+		s.False(s.release.state.IsCompleted("test"))
+	})
+
+	s.Run("step is completed and skipped in the next run", func() {
+		s.release.state = NewReleaseState(s.T().TempDir()+"/state.json", "major", "v1.16.0")
+
+		var calls int
+		action := func() error {
+			calls++
+			return nil
+		}
+
+		s.NoError(s.release.step("test", action))
+		s.NoError(s.release.step("test", action))
+		s.Equal(1, calls)
+		s.True(s.release.state.IsCompleted("test"))
+	})
+
+	s.release.state = nil
+}
+
+func (s *ReleaseTestSuite) Test_updateVersion() {
+	s.Run("the version is the same as the tag", func() {
+		s.NoError(s.release.updateVersion(&ReleaseInformation{
+			currentTag: "v1.16.1",
+			repo:       "framework",
+			tag:        "v1.16.1",
+		}))
+	})
+
+	s.Run("the repo has no version file", func() {
+		s.NoError(s.release.updateVersion(&ReleaseInformation{
+			repo: "gin",
+			tag:  "v1.16.1",
+		}))
+	})
+
+	s.Run("creates the version PR and waits for it to be merged", func() {
+		releaseInfo := &ReleaseInformation{
+			branch:     "v1.16.x",
+			currentTag: "v1.16.0",
+			repo:       "framework",
+			tag:        "v1.16.1",
+		}
+
+		s.mockContext.EXPECT().Spinner("Creating version PR for framework...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGit.EXPECT().Clone("git@github.com:goravel/framework.git", mock.AnythingOfType("string")).
+			RunAndReturn(func(url, dir string) error {
+				s.NoError(os.MkdirAll(filepath.Join(dir, "support"), 0755))
+
+				return os.WriteFile(filepath.Join(dir, "support", "constant.go"), []byte(`const Version string = "v1.16.0"`), 0644)
+			}).Once()
+		s.mockGit.EXPECT().Checkout(mock.AnythingOfType("string"), "v1.16.x").Return(nil).Once()
+		s.mockGit.EXPECT().CreateBranch(mock.AnythingOfType("string"), "auto-version/v1.16.1").Return(nil).Once()
+		s.mockGit.EXPECT().DiffStat(mock.AnythingOfType("string")).Return("support/constant.go | 2 +-", nil).Once()
+		s.mockGit.EXPECT().Commit(mock.AnythingOfType("string"), "chore: Update version to v1.16.1 (auto)").Return(nil).Once()
+		s.mockGit.EXPECT().Push(mock.AnythingOfType("string"), "auto-version/v1.16.1", true).Return(nil).Once()
+		pr := &github.PullRequest{
+			Number:  convert.Pointer(1),
+			Title:   convert.Pointer("chore: Update version to v1.16.1 (auto)"),
+			HTMLURL: convert.Pointer("https://github.com/goravel/framework/pull/1"),
+		}
+		s.mockGithub.EXPECT().GetPullRequests(owner, "framework", &github.PullRequestListOptions{
+			State: "open",
+		}).Return([]*github.PullRequest{pr}, nil).Once()
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/framework", color.Green().Sprint("PASS")).Return().Once()
+		s.mockContext.EXPECT().Choice("Check PRs merge status?", []console.Choice{
+			{
+				Key:   "Check",
+				Value: "Check",
+			},
+		}).Return("Check", nil).Once()
+		s.mockContext.EXPECT().Spinner("Checking goravel/framework merge status...", mock.AnythingOfType("console.SpinnerOption")).
+			RunAndReturn(func(msg string, opts console.SpinnerOption) error {
+				return opts.Action()
+			}).Once()
+		s.mockGithub.EXPECT().GetPullRequest(owner, "framework", 1).Return(&github.PullRequest{
+			Merged: convert.Pointer(true),
+		}, nil).Once()
+
+		s.NoError(s.release.updateVersion(releaseInfo))
+		s.Equal("v1.16.1", releaseInfo.currentTag)
+	})
+}
+
+func (s *ReleaseTestSuite) Test_verifyModules() {
+	repoToTag := map[string]string{"framework": "v1.17.0", "gin": "v1.17.0", "goravel-lite": "v1.17.0"}
+	expectSummary := func(frameworkStatus, ginStatus string) {
+		s.mockContext.EXPECT().TwoColumnDetail("", "", '-').Return().Twice()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/framework", frameworkStatus).Return().Once()
+		s.mockContext.EXPECT().TwoColumnDetail("goravel/gin", ginStatus).Return().Once()
+	}
+	notFound := errors.New("failed to get https://proxy.golang.org/github.com/goravel/gin/@v/v1.17.0.info: 404 not found")
+
+	tests := []struct {
+		name         string
+		setup        func()
+		wantErr      string
+		wantExitCode int
+	}{
+		{
+			name: "fetchable, the apps are skipped",
+			setup: func() {
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/framework", "v1.17.0").Return(nil).Once()
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/gin", "v1.17.0").Return(nil).Once()
+				expectSummary(color.Green().Sprint("PASS"), color.Green().Sprint("PASS"))
+			},
+		},
+		{
+			name: "fetchable after a retry",
+			setup: func() {
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/framework", "v1.17.0").Return(nil).Once()
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/gin", "v1.17.0").Return(notFound).Once()
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/gin", "v1.17.0").Return(nil).Once()
+				expectSummary(color.Green().Sprint("PASS"), color.Green().Sprint("PASS"))
+			},
+		},
+		{
+			name: "not fetchable after the retries",
+			setup: func() {
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/framework", "v1.17.0").Return(nil).Once()
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/gin", "v1.17.0").Return(notFound).Times(3)
+				expectSummary(color.Green().Sprint("PASS"), color.Red().Sprint("FAIL"))
+			},
+			wantErr:      "github.com/goravel/gin@v1.17.0 is not fetchable after 3 attempts: " + notFound.Error(),
+			wantExitCode: ExitCodePending,
+		},
+		{
+			name: "checksum mismatch isn't retried",
+			setup: func() {
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/framework", "v1.17.0").
+					Return(fmt.Errorf("%w: github.com/goravel/framework v1.17.0 is h1:a= in the proxy, but h1:b= in the checksum database", services.ErrChecksumMismatch)).Once()
+				s.mockGoProxy.EXPECT().Verify("github.com/goravel/gin", "v1.17.0").Return(nil).Once()
+				expectSummary(color.Red().Sprint("FAIL"), color.Green().Sprint("PASS"))
+			},
+			wantErr:      "checksum mismatch: github.com/goravel/framework v1.17.0 is h1:a= in the proxy, but h1:b= in the checksum database",
+			wantExitCode: ExitCodeChecksFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.release.pollInterval = time.Millisecond
+			tt.setup()
+
+			err := s.release.verifyModules(repoToTag, 3)
+
+			if tt.wantErr == "" {
+				s.NoError(err)
+			} else {
+				s.EqualError(err, tt.wantErr)
+				s.Equal(tt.wantExitCode, ExitCode(err))
+			}
+		})
+	}
+}
+
+func (s *ReleaseTestSuite) Test_version() {
+	s.Run("valid tag", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("v1.17.0-rc.1").Once()
+
+		version, err := s.release.version()
+
+		s.NoError(err)
+		s.Equal("v1.17.0-rc.1", version.String())
+	})
+
+	s.Run("invalid tag", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("1.17.0").Once()
+
+		version, err := s.release.version()
+
+		s.Nil(version)
+		s.EqualError(err, `invalid tag "1.17.0", it should start with v, e.g. v1.17.0`)
+	})
+
+	s.Run("invalid tag is rejected before releasing", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("v1.17").Once()
+
+		s.EqualError(s.release.Patch(), `invalid tag "v1.17", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`)
+	})
+
+	s.Run("prerelease is rejected by major and patch", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("v1.17.0-rc.1").Twice()
+
+		s.EqualError(s.release.Major(), "v1.17.0-rc.1 is a prerelease, release it via the rc command")
+		s.EqualError(s.release.Patch(), "v1.17.0-rc.1 is a prerelease, release it via the rc command")
+	})
+
+	s.Run("final version is rejected by rc", func() {
+		s.mockContext.EXPECT().ArgumentString("tag").Return("v1.17.0").Once()
+
+		s.EqualError(s.release.RC(), "v1.17.0 is not a prerelease, e.g. v1.17.0-rc.1")
+	})
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Rollback struct{}
+
+func NewRollback() *Rollback {
+	return &Rollback{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Rollback) Signature() string {
+	return "rollback"
+}
+
+// Description The console command description.
+func (r *Rollback) Description() string {
+	return "Roll back the GitHub changes of a partially completed release"
+}
+
+// Extend The console command extend.
+func (r *Rollback) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The tag of the release to roll back, e.g. v1.17.0",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "real",
+				Aliases: []string{"r"},
+				Usage:   "Real rollback, the changes are only listed otherwise",
+			},
+			&command.BoolFlag{
+				Name:    "non-interactive",
+				Aliases: []string{"yes", "y"},
+				Usage:   "Answer every question with its policy answer instead of asking, nothing is undone given every step is destructive",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Rollback) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Rollback())
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Smoke struct{}
+
+func NewSmoke() *Smoke {
+	return &Smoke{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Smoke) Signature() string {
+	return "smoke"
+}
+
+// Description The console command description.
+func (r *Smoke) Description() string {
+	return "Smoke test a new project installed via goravel/installer at the released tag"
+}
+
+// Extend The console command extend.
+func (r *Smoke) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The released tag to install, e.g. v1.17.0",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "goproxy",
+				Usage: "The GOPROXY of the go commands, e.g. a local module proxy in CI, default the current GOPROXY",
+			},
+			&command.StringFlag{
+				Name:  "goflags",
+				Usage: "The GOFLAGS of the go commands, e.g. -mod=mod, default the current GOFLAGS",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Smoke) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Smoke())
+}
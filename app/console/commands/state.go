@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ReleaseState records the progress of a release, it's saved after every completed step,
+// so a failed release can be resumed from the first unfinished step via the --resume flag.
+type ReleaseState struct {
+	// The command that created the state, major or patch
+	Command string `json:"command"`
+	// The tag to release
+	Tag string `json:"tag"`
+	// The completed steps, in order of completion
+	Steps []string `json:"steps"`
+	// The upgrade PR numbers, keyed by repo
+	PRs map[string]int `json:"prs"`
+	// The released tags, keyed by repo
+	Tags map[string]string `json:"tags"`
+	// The resources created on GitHub, in order of creation, they are undone by the rollback command
+	Created []*CreatedResource `json:"created,omitempty"`
+	// The last time the state was saved
+	UpdatedAt time.Time `json:"updated_at"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// CreatedResourceKind is the kind of a resource created on GitHub by a release.
+type CreatedResourceKind string
+
+const (
+	CreatedBranch        CreatedResourceKind = "branch"
+	CreatedDefaultBranch CreatedResourceKind = "default_branch"
+	CreatedPullRequest   CreatedResourceKind = "pull_request"
+	CreatedRelease       CreatedResourceKind = "release"
+)
+
+type CreatedResource struct {
+	// The resource kind
+	Kind CreatedResourceKind `json:"kind"`
+	// The repo name
+	Repo string `json:"repo"`
+	// The released tag, the pushed branch, the new default branch or the head branch of the pull request
+	Name string `json:"name"`
+	// The release ID or the pull request number
+	ID int64 `json:"id,omitempty"`
+	// The default branch before it was changed
+	Previous string `json:"previous,omitempty"`
+}
+
+func NewReleaseState(path, command, tag string) *ReleaseState {
+	return &ReleaseState{
+		Command: command,
+		Tag:     tag,
+		PRs:     make(map[string]int),
+		Tags:    make(map[string]string),
+		path:    path,
+	}
+}
+
+func LoadReleaseState(path string) (*ReleaseState, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("release state %s does not exist, nothing to resume", path)
+		}
+
+		return nil, fmt.Errorf("failed to read release state %s: %w", path, err)
+	}
+
+	state := NewReleaseState(path, "", "")
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("failed to parse release state %s: %w", path, err)
+	}
+	if state.PRs == nil {
+		state.PRs = make(map[string]int)
+	}
+	if state.Tags == nil {
+		state.Tags = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// AddCreated records the resource created on GitHub and saves the state.
+func (r *ReleaseState) AddCreated(resource *CreatedResource) error {
+	r.mu.Lock()
+	r.Created = append(r.Created, resource)
+	r.mu.Unlock()
+
+	return r.Save()
+}
+
+// Complete marks the step as completed and saves the state.
+func (r *ReleaseState) Complete(step string) error {
+	r.mu.Lock()
+	if !slices.Contains(r.Steps, step) {
+		r.Steps = append(r.Steps, step)
+	}
+	r.mu.Unlock()
+
+	return r.Save()
+}
+
+// Delete removes the saved state, e.g. once the release has been rolled back.
+func (r *ReleaseState) Delete() error {
+	if err := os.Remove(r.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete release state %s: %w", r.path, err)
+	}
+
+	return nil
+}
+
+// IsCompleted reports whether the step has been completed in a previous run.
+func (r *ReleaseState) IsCompleted(step string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Contains(r.Steps, step)
+}
+
+// PR returns the upgrade PR number recorded for the repo.
+func (r *ReleaseState) PR(repo string) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	number, exist := r.PRs[repo]
+
+	return number, exist
+}
+
+func (r *ReleaseState) Path() string {
+	return r.path
+}
+
+func (r *ReleaseState) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.UpdatedAt = time.Now()
+
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode release state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create release state directory: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, content, 0644); err != nil {
+		return fmt.Errorf("failed to save release state %s: %w", r.path, err)
+	}
+
+	return nil
+}
+
+// SetPR records the upgrade PR number for the repo and saves the state.
+func (r *ReleaseState) SetPR(repo string, number int) error {
+	r.mu.Lock()
+	r.PRs[repo] = number
+	r.mu.Unlock()
+
+	return r.Save()
+}
+
+// SetTag records the released tag for the repo and saves the state.
+func (r *ReleaseState) SetTag(repo, tag string) error {
+	r.mu.Lock()
+	r.Tags[repo] = tag
+	r.mu.Unlock()
+
+	return r.Save()
+}
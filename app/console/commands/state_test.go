@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release", "major-v1.16.0.json")
+
+	_, err := LoadReleaseState(path)
+	assert.EqualError(t, err, "release state "+path+" does not exist, nothing to resume")
+
+	state := NewReleaseState(path, "major", "v1.16.0")
+	assert.NoError(t, state.Complete("release:framework"))
+	assert.NoError(t, state.Complete("release:framework"))
+	assert.NoError(t, state.SetPR("gin", 12))
+	assert.NoError(t, state.SetTag("framework", "v1.16.0"))
+	assert.NoError(t, state.AddCreated(&CreatedResource{Kind: CreatedRelease, Repo: "framework", Name: "v1.16.0", ID: 3}))
+	assert.NoError(t, state.AddCreated(&CreatedResource{Kind: CreatedDefaultBranch, Repo: "framework", Name: "v1.16.x", Previous: "master"}))
+
+	loaded, err := LoadReleaseState(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "major", loaded.Command)
+	assert.Equal(t, "v1.16.0", loaded.Tag)
+	assert.Equal(t, []string{"release:framework"}, loaded.Steps)
+	assert.True(t, loaded.IsCompleted("release:framework"))
+	assert.False(t, loaded.IsCompleted("branch:framework"))
+	assert.Equal(t, map[string]string{"framework": "v1.16.0"}, loaded.Tags)
+	assert.Equal(t, []*CreatedResource{
+		{Kind: CreatedRelease, Repo: "framework", Name: "v1.16.0", ID: 3},
+		{Kind: CreatedDefaultBranch, Repo: "framework", Name: "v1.16.x", Previous: "master"},
+	}, loaded.Created)
+	assert.Equal(t, path, loaded.Path())
+
+	number, exist := loaded.PR("gin")
+	assert.True(t, exist)
+	assert.Equal(t, 12, number)
+
+	_, exist = loaded.PR("fiber")
+	assert.False(t, exist)
+
+	assert.NoError(t, os.WriteFile(path, []byte("invalid"), 0644))
+	_, err = LoadReleaseState(path)
+	assert.ErrorContains(t, err, "failed to parse release state")
+
+	assert.NoError(t, loaded.Delete())
+	assert.NoFileExists(t, path)
+	assert.NoError(t, loaded.Delete())
+}
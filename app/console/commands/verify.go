@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+type Verify struct{}
+
+func NewVerify() *Verify {
+	return &Verify{}
+}
+
+// Signature The name and signature of the console command.
+func (r *Verify) Signature() string {
+	return "verify"
+}
+
+// Description The console command description.
+func (r *Verify) Description() string {
+	return "Verify the Go module proxy serves the released tags"
+}
+
+// Extend The console command extend.
+func (r *Verify) Extend() command.Extend {
+	return command.Extend{
+		Category: "release",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "tag",
+				Usage:    "The released tag to verify, e.g. v1.17.0",
+				Required: true,
+			},
+		},
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "patch",
+				Aliases: []string{"p"},
+				Usage:   "Verify a patch release, only the framework is released",
+			},
+			&command.IntFlag{
+				Name:  "retries",
+				Value: defaultVerifyRetries,
+				Usage: "The number of attempts to fetch a module that isn't fetchable yet",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *Verify) Handle(ctx console.Context) error {
+	release, err := NewRelease(ctx)
+	if err != nil {
+		return exitError(err)
+	}
+
+	return exitError(release.Verify())
+}
@@ -5,6 +5,7 @@ package services
 import (
 	github "github.com/google/go-github/v84/github"
 	mock "github.com/stretchr/testify/mock"
+	services "goravel/app/services"
 )
 
 // Github is an autogenerated mock type for the Github type
@@ -78,6 +79,115 @@ func (_c *Github_CheckBranchExists_Call) RunAndReturn(run func(string, string, s
 	return _c
 }
 
+// ClosePullRequest provides a mock function with given fields: owner, repo, number
+func (_m *Github) ClosePullRequest(owner string, repo string, number int) error {
+	ret := _m.Called(owner, repo, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePullRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int) error); ok {
+		r0 = rf(owner, repo, number)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Github_ClosePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClosePullRequest'
+type Github_ClosePullRequest_Call struct {
+	*mock.Call
+}
+
+// ClosePullRequest is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - number int
+func (_e *Github_Expecter) ClosePullRequest(owner interface{}, repo interface{}, number interface{}) *Github_ClosePullRequest_Call {
+	return &Github_ClosePullRequest_Call{Call: _e.mock.On("ClosePullRequest", owner, repo, number)}
+}
+
+func (_c *Github_ClosePullRequest_Call) Run(run func(owner string, repo string, number int)) *Github_ClosePullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Github_ClosePullRequest_Call) Return(_a0 error) *Github_ClosePullRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Github_ClosePullRequest_Call) RunAndReturn(run func(string, string, int) error) *Github_ClosePullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompareCommits provides a mock function with given fields: owner, repo, base, head
+func (_m *Github) CompareCommits(owner string, repo string, base string, head string) ([]*github.RepositoryCommit, error) {
+	ret := _m.Called(owner, repo, base, head)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareCommits")
+	}
+
+	var r0 []*github.RepositoryCommit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) ([]*github.RepositoryCommit, error)); ok {
+		return rf(owner, repo, base, head)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, string) []*github.RepositoryCommit); ok {
+		r0 = rf(owner, repo, base, head)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.RepositoryCommit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(owner, repo, base, head)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_CompareCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareCommits'
+type Github_CompareCommits_Call struct {
+	*mock.Call
+}
+
+// CompareCommits is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - base string
+//   - head string
+func (_e *Github_Expecter) CompareCommits(owner interface{}, repo interface{}, base interface{}, head interface{}) *Github_CompareCommits_Call {
+	return &Github_CompareCommits_Call{Call: _e.mock.On("CompareCommits", owner, repo, base, head)}
+}
+
+func (_c *Github_CompareCommits_Call) Run(run func(owner string, repo string, base string, head string)) *Github_CompareCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Github_CompareCommits_Call) Return(_a0 []*github.RepositoryCommit, _a1 error) *Github_CompareCommits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_CompareCommits_Call) RunAndReturn(run func(string, string, string, string) ([]*github.RepositoryCommit, error)) *Github_CompareCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePullRequest provides a mock function with given fields: owner, repo, pr
 func (_m *Github) CreatePullRequest(owner string, repo string, pr *github.NewPullRequest) (*github.PullRequest, error) {
 	ret := _m.Called(owner, repo, pr)
@@ -198,6 +308,150 @@ func (_c *Github_CreateRelease_Call) RunAndReturn(run func(string, string, *gith
 	return _c
 }
 
+// DeleteBranch provides a mock function with given fields: owner, repo, branch
+func (_m *Github) DeleteBranch(owner string, repo string, branch string) error {
+	ret := _m.Called(owner, repo, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBranch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(owner, repo, branch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Github_DeleteBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBranch'
+type Github_DeleteBranch_Call struct {
+	*mock.Call
+}
+
+// DeleteBranch is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - branch string
+func (_e *Github_Expecter) DeleteBranch(owner interface{}, repo interface{}, branch interface{}) *Github_DeleteBranch_Call {
+	return &Github_DeleteBranch_Call{Call: _e.mock.On("DeleteBranch", owner, repo, branch)}
+}
+
+func (_c *Github_DeleteBranch_Call) Run(run func(owner string, repo string, branch string)) *Github_DeleteBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_DeleteBranch_Call) Return(_a0 error) *Github_DeleteBranch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Github_DeleteBranch_Call) RunAndReturn(run func(string, string, string) error) *Github_DeleteBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRelease provides a mock function with given fields: owner, repo, id
+func (_m *Github) DeleteRelease(owner string, repo string, id int64) error {
+	ret := _m.Called(owner, repo, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = rf(owner, repo, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Github_DeleteRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelease'
+type Github_DeleteRelease_Call struct {
+	*mock.Call
+}
+
+// DeleteRelease is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - id int64
+func (_e *Github_Expecter) DeleteRelease(owner interface{}, repo interface{}, id interface{}) *Github_DeleteRelease_Call {
+	return &Github_DeleteRelease_Call{Call: _e.mock.On("DeleteRelease", owner, repo, id)}
+}
+
+func (_c *Github_DeleteRelease_Call) Run(run func(owner string, repo string, id int64)) *Github_DeleteRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Github_DeleteRelease_Call) Return(_a0 error) *Github_DeleteRelease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Github_DeleteRelease_Call) RunAndReturn(run func(string, string, int64) error) *Github_DeleteRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: owner, repo, tag
+func (_m *Github) DeleteTag(owner string, repo string, tag string) error {
+	ret := _m.Called(owner, repo, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(owner, repo, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Github_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type Github_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - tag string
+func (_e *Github_Expecter) DeleteTag(owner interface{}, repo interface{}, tag interface{}) *Github_DeleteTag_Call {
+	return &Github_DeleteTag_Call{Call: _e.mock.On("DeleteTag", owner, repo, tag)}
+}
+
+func (_c *Github_DeleteTag_Call) Run(run func(owner string, repo string, tag string)) *Github_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_DeleteTag_Call) Return(_a0 error) *Github_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Github_DeleteTag_Call) RunAndReturn(run func(string, string, string) error) *Github_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateReleaseNotes provides a mock function with given fields: owner, repo, opts
 func (_m *Github) GenerateReleaseNotes(owner string, repo string, opts *github.GenerateNotesOptions) (*github.RepositoryReleaseNotes, error) {
 	ret := _m.Called(owner, repo, opts)
@@ -258,29 +512,206 @@ func (_c *Github_GenerateReleaseNotes_Call) RunAndReturn(run func(string, string
 	return _c
 }
 
-// GetLatestRelease provides a mock function with given fields: owner, repo, tag
-func (_m *Github) GetLatestRelease(owner string, repo string, tag string) (*github.RepositoryRelease, error) {
-	ret := _m.Called(owner, repo, tag)
+// GetCheckRuns provides a mock function with given fields: owner, repo, ref
+func (_m *Github) GetCheckRuns(owner string, repo string, ref string) ([]*github.CheckRun, error) {
+	ret := _m.Called(owner, repo, ref)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLatestRelease")
+		panic("no return value specified for GetCheckRuns")
 	}
 
-	var r0 *github.RepositoryRelease
+	var r0 []*github.CheckRun
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*github.RepositoryRelease, error)); ok {
-		return rf(owner, repo, tag)
+	if rf, ok := ret.Get(0).(func(string, string, string) ([]*github.CheckRun, error)); ok {
+		return rf(owner, repo, ref)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) *github.RepositoryRelease); ok {
-		r0 = rf(owner, repo, tag)
+	if rf, ok := ret.Get(0).(func(string, string, string) []*github.CheckRun); ok {
+		r0 = rf(owner, repo, ref)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.RepositoryRelease)
+			r0 = ret.Get(0).([]*github.CheckRun)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(owner, repo, tag)
+		r1 = rf(owner, repo, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetCheckRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckRuns'
+type Github_GetCheckRuns_Call struct {
+	*mock.Call
+}
+
+// GetCheckRuns is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - ref string
+func (_e *Github_Expecter) GetCheckRuns(owner interface{}, repo interface{}, ref interface{}) *Github_GetCheckRuns_Call {
+	return &Github_GetCheckRuns_Call{Call: _e.mock.On("GetCheckRuns", owner, repo, ref)}
+}
+
+func (_c *Github_GetCheckRuns_Call) Run(run func(owner string, repo string, ref string)) *Github_GetCheckRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_GetCheckRuns_Call) Return(_a0 []*github.CheckRun, _a1 error) *Github_GetCheckRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetCheckRuns_Call) RunAndReturn(run func(string, string, string) ([]*github.CheckRun, error)) *Github_GetCheckRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCombinedStatus provides a mock function with given fields: owner, repo, ref
+func (_m *Github) GetCombinedStatus(owner string, repo string, ref string) (*github.CombinedStatus, error) {
+	ret := _m.Called(owner, repo, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCombinedStatus")
+	}
+
+	var r0 *github.CombinedStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*github.CombinedStatus, error)); ok {
+		return rf(owner, repo, ref)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *github.CombinedStatus); ok {
+		r0 = rf(owner, repo, ref)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.CombinedStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(owner, repo, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetCombinedStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCombinedStatus'
+type Github_GetCombinedStatus_Call struct {
+	*mock.Call
+}
+
+// GetCombinedStatus is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - ref string
+func (_e *Github_Expecter) GetCombinedStatus(owner interface{}, repo interface{}, ref interface{}) *Github_GetCombinedStatus_Call {
+	return &Github_GetCombinedStatus_Call{Call: _e.mock.On("GetCombinedStatus", owner, repo, ref)}
+}
+
+func (_c *Github_GetCombinedStatus_Call) Run(run func(owner string, repo string, ref string)) *Github_GetCombinedStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_GetCombinedStatus_Call) Return(_a0 *github.CombinedStatus, _a1 error) *Github_GetCombinedStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetCombinedStatus_Call) RunAndReturn(run func(string, string, string) (*github.CombinedStatus, error)) *Github_GetCombinedStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDefaultBranch provides a mock function with given fields: owner, repo
+func (_m *Github) GetDefaultBranch(owner string, repo string) (string, error) {
+	ret := _m.Called(owner, repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaultBranch")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(owner, repo)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(owner, repo)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(owner, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetDefaultBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDefaultBranch'
+type Github_GetDefaultBranch_Call struct {
+	*mock.Call
+}
+
+// GetDefaultBranch is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+func (_e *Github_Expecter) GetDefaultBranch(owner interface{}, repo interface{}) *Github_GetDefaultBranch_Call {
+	return &Github_GetDefaultBranch_Call{Call: _e.mock.On("GetDefaultBranch", owner, repo)}
+}
+
+func (_c *Github_GetDefaultBranch_Call) Run(run func(owner string, repo string)) *Github_GetDefaultBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Github_GetDefaultBranch_Call) Return(_a0 string, _a1 error) *Github_GetDefaultBranch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetDefaultBranch_Call) RunAndReturn(run func(string, string) (string, error)) *Github_GetDefaultBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestRelease provides a mock function with given fields: owner, repo, tag, includePrerelease
+func (_m *Github) GetLatestRelease(owner string, repo string, tag string, includePrerelease bool) (*services.LatestRelease, error) {
+	ret := _m.Called(owner, repo, tag, includePrerelease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestRelease")
+	}
+
+	var r0 *services.LatestRelease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, bool) (*services.LatestRelease, error)); ok {
+		return rf(owner, repo, tag, includePrerelease)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, bool) *services.LatestRelease); ok {
+		r0 = rf(owner, repo, tag, includePrerelease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.LatestRelease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, bool) error); ok {
+		r1 = rf(owner, repo, tag, includePrerelease)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -297,23 +728,24 @@ type Github_GetLatestRelease_Call struct {
 //   - owner string
 //   - repo string
 //   - tag string
-func (_e *Github_Expecter) GetLatestRelease(owner interface{}, repo interface{}, tag interface{}) *Github_GetLatestRelease_Call {
-	return &Github_GetLatestRelease_Call{Call: _e.mock.On("GetLatestRelease", owner, repo, tag)}
+//   - includePrerelease bool
+func (_e *Github_Expecter) GetLatestRelease(owner interface{}, repo interface{}, tag interface{}, includePrerelease interface{}) *Github_GetLatestRelease_Call {
+	return &Github_GetLatestRelease_Call{Call: _e.mock.On("GetLatestRelease", owner, repo, tag, includePrerelease)}
 }
 
-func (_c *Github_GetLatestRelease_Call) Run(run func(owner string, repo string, tag string)) *Github_GetLatestRelease_Call {
+func (_c *Github_GetLatestRelease_Call) Run(run func(owner string, repo string, tag string, includePrerelease bool)) *Github_GetLatestRelease_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
 	})
 	return _c
 }
 
-func (_c *Github_GetLatestRelease_Call) Return(_a0 *github.RepositoryRelease, _a1 error) *Github_GetLatestRelease_Call {
+func (_c *Github_GetLatestRelease_Call) Return(_a0 *services.LatestRelease, _a1 error) *Github_GetLatestRelease_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Github_GetLatestRelease_Call) RunAndReturn(run func(string, string, string) (*github.RepositoryRelease, error)) *Github_GetLatestRelease_Call {
+func (_c *Github_GetLatestRelease_Call) RunAndReturn(run func(string, string, string, bool) (*services.LatestRelease, error)) *Github_GetLatestRelease_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -378,6 +810,66 @@ func (_c *Github_GetPullRequest_Call) RunAndReturn(run func(string, string, int)
 	return _c
 }
 
+// GetPullRequestFiles provides a mock function with given fields: owner, repo, number
+func (_m *Github) GetPullRequestFiles(owner string, repo string, number int) ([]*github.CommitFile, error) {
+	ret := _m.Called(owner, repo, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPullRequestFiles")
+	}
+
+	var r0 []*github.CommitFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) ([]*github.CommitFile, error)); ok {
+		return rf(owner, repo, number)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) []*github.CommitFile); ok {
+		r0 = rf(owner, repo, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.CommitFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(owner, repo, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetPullRequestFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPullRequestFiles'
+type Github_GetPullRequestFiles_Call struct {
+	*mock.Call
+}
+
+// GetPullRequestFiles is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - number int
+func (_e *Github_Expecter) GetPullRequestFiles(owner interface{}, repo interface{}, number interface{}) *Github_GetPullRequestFiles_Call {
+	return &Github_GetPullRequestFiles_Call{Call: _e.mock.On("GetPullRequestFiles", owner, repo, number)}
+}
+
+func (_c *Github_GetPullRequestFiles_Call) Run(run func(owner string, repo string, number int)) *Github_GetPullRequestFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Github_GetPullRequestFiles_Call) Return(_a0 []*github.CommitFile, _a1 error) *Github_GetPullRequestFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetPullRequestFiles_Call) RunAndReturn(run func(string, string, int) ([]*github.CommitFile, error)) *Github_GetPullRequestFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPullRequests provides a mock function with given fields: owner, repo, opts
 func (_m *Github) GetPullRequests(owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
 	ret := _m.Called(owner, repo, opts)
@@ -438,6 +930,66 @@ func (_c *Github_GetPullRequests_Call) RunAndReturn(run func(string, string, *gi
 	return _c
 }
 
+// GetReleaseByTag provides a mock function with given fields: owner, repo, tag
+func (_m *Github) GetReleaseByTag(owner string, repo string, tag string) (*github.RepositoryRelease, error) {
+	ret := _m.Called(owner, repo, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleaseByTag")
+	}
+
+	var r0 *github.RepositoryRelease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*github.RepositoryRelease, error)); ok {
+		return rf(owner, repo, tag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *github.RepositoryRelease); ok {
+		r0 = rf(owner, repo, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryRelease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(owner, repo, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetReleaseByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReleaseByTag'
+type Github_GetReleaseByTag_Call struct {
+	*mock.Call
+}
+
+// GetReleaseByTag is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - tag string
+func (_e *Github_Expecter) GetReleaseByTag(owner interface{}, repo interface{}, tag interface{}) *Github_GetReleaseByTag_Call {
+	return &Github_GetReleaseByTag_Call{Call: _e.mock.On("GetReleaseByTag", owner, repo, tag)}
+}
+
+func (_c *Github_GetReleaseByTag_Call) Run(run func(owner string, repo string, tag string)) *Github_GetReleaseByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_GetReleaseByTag_Call) Return(_a0 *github.RepositoryRelease, _a1 error) *Github_GetReleaseByTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetReleaseByTag_Call) RunAndReturn(run func(string, string, string) (*github.RepositoryRelease, error)) *Github_GetReleaseByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetReleases provides a mock function with given fields: owner, repo, opts
 func (_m *Github) GetReleases(owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, error) {
 	ret := _m.Called(owner, repo, opts)
@@ -498,6 +1050,115 @@ func (_c *Github_GetReleases_Call) RunAndReturn(run func(string, string, *github
 	return _c
 }
 
+// GetRequiredStatusChecks provides a mock function with given fields: owner, repo, branch
+func (_m *Github) GetRequiredStatusChecks(owner string, repo string, branch string) ([]string, error) {
+	ret := _m.Called(owner, repo, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequiredStatusChecks")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) ([]string, error)); ok {
+		return rf(owner, repo, branch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) []string); ok {
+		r0 = rf(owner, repo, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(owner, repo, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Github_GetRequiredStatusChecks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequiredStatusChecks'
+type Github_GetRequiredStatusChecks_Call struct {
+	*mock.Call
+}
+
+// GetRequiredStatusChecks is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - branch string
+func (_e *Github_Expecter) GetRequiredStatusChecks(owner interface{}, repo interface{}, branch interface{}) *Github_GetRequiredStatusChecks_Call {
+	return &Github_GetRequiredStatusChecks_Call{Call: _e.mock.On("GetRequiredStatusChecks", owner, repo, branch)}
+}
+
+func (_c *Github_GetRequiredStatusChecks_Call) Run(run func(owner string, repo string, branch string)) *Github_GetRequiredStatusChecks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Github_GetRequiredStatusChecks_Call) Return(_a0 []string, _a1 error) *Github_GetRequiredStatusChecks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Github_GetRequiredStatusChecks_Call) RunAndReturn(run func(string, string, string) ([]string, error)) *Github_GetRequiredStatusChecks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePullRequest provides a mock function with given fields: owner, repo, number, method
+func (_m *Github) MergePullRequest(owner string, repo string, number int, method string) error {
+	ret := _m.Called(owner, repo, number, method)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergePullRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, string) error); ok {
+		r0 = rf(owner, repo, number, method)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Github_MergePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePullRequest'
+type Github_MergePullRequest_Call struct {
+	*mock.Call
+}
+
+// MergePullRequest is a helper method to define mock.On call
+//   - owner string
+//   - repo string
+//   - number int
+//   - method string
+func (_e *Github_Expecter) MergePullRequest(owner interface{}, repo interface{}, number interface{}, method interface{}) *Github_MergePullRequest_Call {
+	return &Github_MergePullRequest_Call{Call: _e.mock.On("MergePullRequest", owner, repo, number, method)}
+}
+
+func (_c *Github_MergePullRequest_Call) Run(run func(owner string, repo string, number int, method string)) *Github_MergePullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Github_MergePullRequest_Call) Return(_a0 error) *Github_MergePullRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Github_MergePullRequest_Call) RunAndReturn(run func(string, string, int, string) error) *Github_MergePullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetDefaultBranch provides a mock function with given fields: owner, repo, branch
 func (_m *Github) SetDefaultBranch(owner string, repo string, branch string) error {
 	ret := _m.Called(owner, repo, branch)
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/apidiff"
+	"golang.org/x/tools/go/packages"
+)
+
+type APIDiff interface {
+	// Incompatible returns the incompatible changes of the exported API of the module in newDir against the module
+	// in oldDir, e.g. ./http.Context.Request: removed. The main and internal packages are not compared, and neither
+	// are the values of constants, given the Version constant changes in every release.
+	Incompatible(oldDir, newDir string) ([]string, error)
+}
+
+type APIDiffImpl struct{}
+
+func NewAPIDiffImpl() *APIDiffImpl {
+	return &APIDiffImpl{}
+}
+
+func (r *APIDiffImpl) Incompatible(oldDir, newDir string) ([]string, error) {
+	oldModule, err := r.load(oldDir)
+	if err != nil {
+		return nil, err
+	}
+
+	newModule, err := r.load(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, change := range apidiff.ModuleChanges(oldModule, newModule).Changes {
+		if !change.Compatible && !strings.Contains(change.Message, ": value changed from ") {
+			changes = append(changes, change.Message)
+		}
+	}
+	slices.Sort(changes)
+
+	return changes, nil
+}
+
+// load type-checks the packages of the module in the dir, the dependencies are downloaded if needed. The
+// dependencies are type-checked from source instead of the export data, so the check doesn't depend on the
+// export data format of the installed Go version.
+func (r *APIDiffImpl) load(dir string) (*apidiff.Module, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedModule | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax,
+		Dir:  dir,
+	}, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages in %s: %w", dir, err)
+	}
+
+	module := &apidiff.Module{}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("failed to load package %s in %s: %v", pkg.PkgPath, dir, pkg.Errors[0])
+		}
+		if pkg.Module != nil {
+			module.Path = pkg.Module.Path
+		}
+		if pkg.Name == "main" || isInternalPackage(pkg.PkgPath) {
+			continue
+		}
+
+		module.Packages = append(module.Packages, pkg.Types)
+	}
+
+	return module, nil
+}
+
+func isInternalPackage(path string) bool {
+	return strings.Contains("/"+path+"/", "/internal/")
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIDiffImpl_Incompatible(t *testing.T) {
+	oldFiles := map[string]string{
+		"go.mod":              "module github.com/goravel/framework\n\ngo 1.22\n",
+		"http/context.go":     "package http\n\ntype Context interface {\n\tRequest() string\n\tResponse() string\n}\n\nfunc New() Context { return nil }\n",
+		"support/constant.go": "package support\n\nconst Version = \"v1.16.0\"\n",
+		"internal/helper.go":  "package internal\n\nfunc Helper() {}\n",
+		"cmd/release/main.go": "package main\n\nfunc main() {}\n",
+	}
+
+	tests := []struct {
+		name     string
+		newFiles map[string]string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name: "compatible",
+			newFiles: map[string]string{
+				"support/constant.go": "package support\n\nconst Version = \"v1.16.1\"\n\nconst Name = \"goravel\"\n",
+				"internal/helper.go":  "package internal\n",
+				"cmd/release/main.go": "package main\n\nfunc main() {}\n\nfunc Run() {}\n",
+			},
+		},
+		{
+			name: "incompatible",
+			newFiles: map[string]string{
+				"http/context.go":     "package http\n\ntype Context interface {\n\tRequest() string\n}\n\nfunc New(name string) Context { return nil }\n",
+				"support/constant.go": "",
+			},
+			want: []string{
+				"./http.New: changed from func() Context to func(string) Context",
+				"./http.Context.Response: removed",
+				"package github.com/goravel/framework/support: removed",
+			},
+		},
+		{
+			name: "failed to compile",
+			newFiles: map[string]string{
+				"http/context.go": "package http\n\nfunc New() Context { return nil }\n",
+			},
+			wantErr: "failed to load package github.com/goravel/framework/http",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldDir := writeModule(t, oldFiles, nil)
+			newDir := writeModule(t, oldFiles, tt.newFiles)
+
+			changes, err := NewAPIDiffImpl().Incompatible(oldDir, newDir)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, changes)
+		})
+	}
+}
+
+// writeModule writes the files with the overrides into a temp dir, an empty override removes the file.
+func writeModule(t *testing.T, files, overrides map[string]string) string {
+	dir := t.TempDir()
+	for path, content := range files {
+		if override, exist := overrides[path]; exist {
+			content = override
+		}
+		if content == "" {
+			continue
+		}
+
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type AuditEntry struct {
+	// The time the action was performed
+	Time time.Time `json:"time"`
+	// Who performed the action, see NewAuditLog
+	Operator string `json:"operator"`
+	// The command performing the action, e.g. major or rollback
+	Command string `json:"command"`
+	// The tag of the release
+	Tag string `json:"tag"`
+	// The action type, the same as the actions recorded in preview mode
+	Action PlanActionType `json:"action"`
+	// The repo owner
+	Owner string `json:"owner"`
+	// The repo name
+	Repo string `json:"repo"`
+	// The exact payload the action sent, e.g. *github.NewPullRequest for create_pull_request
+	Payload any `json:"payload"`
+	// The ID of the result, e.g. the release ID or the pull request number
+	ID int64 `json:"id,omitempty"`
+	// The URL of the result, e.g. the HTML URL of the created release or the URL the branch is pushed to
+	URL string `json:"url,omitempty"`
+	// The error if the action failed
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog appends every mutating action performed in real mode to a JSONL file, one entry per line, so a release
+// can be reconstructed afterwards. The file is shared by all releases.
+type AuditLog struct {
+	command  string
+	mu       sync.Mutex
+	operator string
+	path     string
+	tag      string
+}
+
+// NewAuditLog creates the audit log appended to the path, the empty operator defaults to the current OS user.
+func NewAuditLog(path, operator string) *AuditLog {
+	if operator == "" {
+		if current, err := user.Current(); err == nil {
+			operator = current.Username
+		}
+	}
+
+	return &AuditLog{path: path, operator: operator}
+}
+
+// SetRelease sets the command and the tag of the following entries, it's called once the tag is resolved.
+func (r *AuditLog) SetRelease(command, tag string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.command = command
+	r.tag = tag
+}
+
+// Append appends the action to the file, the failure of the action is recorded as well.
+func (r *AuditLog) Append(actionType PlanActionType, owner, repo string, payload any, id int64, url string, actionErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := &AuditEntry{
+		Time:     time.Now(),
+		Operator: r.operator,
+		Command:  r.command,
+		Tag:      r.tag,
+		Action:   actionType,
+		Owner:    owner,
+		Repo:     repo,
+		Payload:  payload,
+		ID:       id,
+		URL:      url,
+	}
+	if actionErr != nil {
+		entry.Error = actionErr.Error()
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", r.path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if _, err := file.Write(append(content, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", r.path, err)
+	}
+
+	return nil
+}
+
+func (r *AuditLog) Path() string {
+	return r.path
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release", "audit.jsonl")
+
+	auditLog := NewAuditLog(path, "hwbrzzl")
+	auditLog.SetRelease("major", "v1.17.0")
+	require.NoError(t, auditLog.Append(PlanActionCreateRelease, "goravel", "framework", map[string]string{"tag_name": "v1.17.0"}, 7, "https://github.com/goravel/framework/releases/tag/v1.17.0", nil))
+
+	auditLog = NewAuditLog(path, "")
+	auditLog.SetRelease("rollback", "v1.17.0")
+	require.NoError(t, auditLog.Append(PlanActionDeleteTag, "goravel", "framework", &DeleteTagPayload{Tag: "v1.17.0"}, 0, "", assert.AnError))
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "hwbrzzl", entries[0].Operator)
+	assert.Equal(t, "major", entries[0].Command)
+	assert.Equal(t, "v1.17.0", entries[0].Tag)
+	assert.Equal(t, PlanActionCreateRelease, entries[0].Action)
+	assert.Equal(t, "framework", entries[0].Repo)
+	assert.Equal(t, map[string]any{"tag_name": "v1.17.0"}, entries[0].Payload)
+	assert.Equal(t, int64(7), entries[0].ID)
+	assert.Equal(t, "https://github.com/goravel/framework/releases/tag/v1.17.0", entries[0].URL)
+	assert.Empty(t, entries[0].Error)
+	assert.False(t, entries[0].Time.IsZero())
+
+	// The operator defaults to the current OS user.
+	assert.NotEmpty(t, entries[1].Operator)
+	assert.Equal(t, "rollback", entries[1].Command)
+	assert.Equal(t, PlanActionDeleteTag, entries[1].Action)
+	assert.Equal(t, map[string]any{"tag": "v1.17.0"}, entries[1].Payload)
+	assert.Equal(t, assert.AnError.Error(), entries[1].Error)
+
+	assert.ErrorContains(t, NewAuditLog(filepath.Join(path, "audit.jsonl"), "").Append(PlanActionDeleteTag, "goravel", "framework", nil, 0, "", nil), "failed to create audit log directory")
+}
+
+func readAuditLog(t *testing.T, path string) []*AuditEntry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var entries []*AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, &entry)
+	}
+
+	return entries
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"goravel/app/facades"
+)
+
+// ErrNothingToCommit is returned by Commit when the working tree has no changes.
+var ErrNothingToCommit = errors.New("nothing to commit, working tree clean")
+
+// GitError is returned when a git command fails, it carries the output of the command.
+type GitError struct {
+	// The git subcommand, e.g. clone
+	Command string
+	// The directory the command ran in
+	Dir string
+	// The combined output of the command
+	Output string
+	// The underlying error
+	Err error
+}
+
+func (r *GitError) Error() string {
+	message := fmt.Sprintf("failed to run git %s in %s: %v", r.Command, r.Dir, r.Err)
+	if r.Output != "" {
+		message += ": " + r.Output
+	}
+
+	return message
+}
+
+func (r *GitError) Unwrap() error {
+	return r.Err
+}
+
+type Git interface {
+	// Checkout checks out an existing branch
+	Checkout(dir, branch string) error
+	// Clone clones the repository into the dir, the dir should be empty
+	Clone(url, dir string) error
+	// Commit stages all changes and commits them, ErrNothingToCommit is returned if there is no change
+	Commit(dir, message string) error
+	// CreateBranch creates a branch from the current HEAD and checks it out, an existing branch is reset
+	CreateBranch(dir, branch string) error
+	// DiffStat returns the diff stat of the working tree against HEAD, untracked files are included
+	DiffStat(dir string) (string, error)
+	// IsClean checks if the working tree has no changes
+	IsClean(dir string) (bool, error)
+	// Push pushes the branch to origin
+	Push(dir, branch string, force bool) error
+}
+
+type GitImpl struct{}
+
+func NewGitImpl() *GitImpl {
+	return &GitImpl{}
+}
+
+func (r *GitImpl) Checkout(dir, branch string) error {
+	_, err := r.run(dir, "checkout", branch)
+
+	return err
+}
+
+func (r *GitImpl) Clone(url, dir string) error {
+	_, err := r.run(dir, "clone", url, ".")
+
+	return err
+}
+
+func (r *GitImpl) Commit(dir, message string) error {
+	clean, err := r.IsClean(dir)
+	if err != nil {
+		return err
+	}
+	if clean {
+		return ErrNothingToCommit
+	}
+
+	if _, err := r.run(dir, "add", "--all"); err != nil {
+		return err
+	}
+
+	_, err = r.run(dir, "commit", "--message", message)
+
+	return err
+}
+
+func (r *GitImpl) CreateBranch(dir, branch string) error {
+	_, err := r.run(dir, "checkout", "-B", branch)
+
+	return err
+}
+
+func (r *GitImpl) DiffStat(dir string) (string, error) {
+	// Mark the untracked files as intent-to-add, so they are included in the diff.
+	if _, err := r.run(dir, "add", "--all", "--intent-to-add"); err != nil {
+		return "", err
+	}
+
+	return r.run(dir, "diff", "--stat", "HEAD")
+}
+
+func (r *GitImpl) IsClean(dir string) (bool, error) {
+	output, err := r.run(dir, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+
+	return output == "", nil
+}
+
+func (r *GitImpl) Push(dir, branch string, force bool) error {
+	args := []string{"push", "origin", branch}
+	if force {
+		args = append(args, "--force")
+	}
+
+	_, err := r.run(dir, args...)
+
+	return err
+}
+
+func (r *GitImpl) run(dir string, args ...string) (string, error) {
+	res := facades.Process().Path(dir).Quietly().Run("git", args...)
+	if res.Failed() {
+		return "", &GitError{
+			Command: args[0],
+			Dir:     dir,
+			Output:  strings.TrimSpace(res.Output() + res.ErrorOutput()),
+			Err:     res.Error(),
+		}
+	}
+
+	return strings.TrimSpace(res.Output()), nil
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v84/github"
@@ -17,42 +19,89 @@ import (
 type Github interface {
 	// CheckBranchExists checks if a branch exists in a repository
 	CheckBranchExists(owner, repo, branch string) (bool, error)
+	// ClosePullRequest closes a pull request without merging it
+	ClosePullRequest(owner, repo string, number int) error
+	// CompareCommits lists the commits reachable from head but not from base, oldest first, e.g. the commits since the previous tag
+	CompareCommits(owner, repo, base, head string) ([]*github.RepositoryCommit, error)
 	// CreatePullRequest creates a new pull request
 	CreatePullRequest(owner, repo string, pr *github.NewPullRequest) (*github.PullRequest, error)
 	// CreateRelease creates a new release
 	CreateRelease(owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error)
+	// DeleteBranch deletes a branch of a repository
+	DeleteBranch(owner, repo, branch string) error
+	// DeleteRelease deletes a release by ID, the tag of the release is kept
+	DeleteRelease(owner, repo string, id int64) error
+	// DeleteTag deletes a git tag of a repository
+	DeleteTag(owner, repo, tag string) error
 	// GenerateReleaseNotes generates release notes for a repository
 	GenerateReleaseNotes(owner, repo string, opts *github.GenerateNotesOptions) (*github.RepositoryReleaseNotes, error)
-	// GetLatestRelease gets the latest release for a repository.
-	// If tag is provided, it will return the latest release with the same major and minor version as the tag.
-	// For example, if tag is v1.16.2, it will return the latest release with tag starting with v1.16.
-	// If no such release is found, it will return the latest release.
-	GetLatestRelease(owner, repo, tag string) (*github.RepositoryRelease, error)
+	// GetCheckRuns lists the latest check runs at the ref, e.g. the GitHub Actions jobs at the head of a branch
+	GetCheckRuns(owner, repo, ref string) ([]*github.CheckRun, error)
+	// GetCombinedStatus gets the combined commit status at the ref, the statuses of all pages are merged
+	GetCombinedStatus(owner, repo, ref string) (*github.CombinedStatus, error)
+	// GetDefaultBranch gets the default branch of a repository
+	GetDefaultBranch(owner, repo string) (string, error)
+	// GetLatestRelease gets the previous release of the tag, it pages through all releases and git tags
+	// and returns the highest version strictly lower than the tag, e.g. v1.16.2 for v1.16.3 even if v1.17.0 is newer.
+	// The drafts are always skipped. The prereleases, i.e. the releases marked as prerelease and the tags with a
+	// prerelease suffix, are skipped unless includePrerelease is set, e.g. v1.17.0-rc.2 returns v1.17.0-rc.1 with it
+	// and v1.16.2 without it. It returns nil if no release or tag is lower than the tag. The latest release is returned
+	// if the tag is empty.
+	GetLatestRelease(owner, repo, tag string, includePrerelease bool) (*LatestRelease, error)
 	// GetPullRequest gets a specific pull request by number
 	GetPullRequest(owner, repo string, number int) (*github.PullRequest, error)
+	// GetPullRequestFiles lists the files changed by a pull request, the files of all pages are merged
+	GetPullRequestFiles(owner, repo string, number int) ([]*github.CommitFile, error)
 	// GetPullRequests lists pull requests for a repository
 	GetPullRequests(owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error)
+	// GetReleaseByTag gets the published release of the tag, it returns nil if the release doesn't exist
+	GetReleaseByTag(owner, repo, tag string) (*github.RepositoryRelease, error)
 	// GetReleases lists releases for a repository
 	GetReleases(owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, error)
+	// GetRequiredStatusChecks gets the names of the checks required by the branch protection, it's empty if the
+	// branch isn't protected or the protection can't be read by the token
+	GetRequiredStatusChecks(owner, repo, branch string) ([]string, error)
+	// MergePullRequest merges a pull request via the merge method: merge, squash or rebase
+	MergePullRequest(owner, repo string, number int, method string) error
 	// SetDefaultBranch sets the default branch for a repository
 	SetDefaultBranch(owner, repo, branch string) error
 }
 
 type GithubImpl struct {
-	ctx    context.Context
-	client *github.Client
-	real   bool
+	// The mutating actions are appended to the audit log if it's real and the log is set
+	auditLog *AuditLog
+	ctx      context.Context
+	client   *github.Client
+	// The mutating actions are recorded in the plan instead of being sent if it's not real
+	plan *Plan
+	real bool
 }
 
-func NewGithubImpl(real bool) *GithubImpl {
+func NewGithubImpl(real bool, plan *Plan, auditLog *AuditLog) *GithubImpl {
 	token := facades.Config().GetString("GITHUB_TOKEN")
 	if token == "" {
 		panic("github token is not set")
 	}
 
+	return newGithubImpl(token, facades.Config().GetString("release.github_url"), real, plan, auditLog)
+}
+
+func newGithubImpl(token, baseURL string, real bool, plan *Plan, auditLog *AuditLog) *GithubImpl {
 	client := github.NewClient(nil).WithAuthToken(token)
+	if baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			panic(fmt.Sprintf("invalid github url %s: %v", baseURL, err))
+		}
+
+		client.BaseURL = parsed
+	}
 
-	return &GithubImpl{ctx: context.Background(), client: client, real: real}
+	return &GithubImpl{auditLog: auditLog, ctx: context.Background(), client: client, plan: plan, real: real}
 }
 
 func (r *GithubImpl) CheckBranchExists(owner, repo, branch string) (bool, error) {
@@ -74,16 +123,63 @@ func (r *GithubImpl) CheckBranchExists(owner, repo, branch string) (bool, error)
 	return true, nil
 }
 
+func (r *GithubImpl) ClosePullRequest(owner, repo string, number int) error {
+	if !r.real {
+		color.Yellow().Println(fmt.Sprintf("Preview mode, skip closing pull request %d for %s/%s", number, owner, repo))
+		r.record(PlanActionClosePullRequest, owner, repo, &ClosePullRequestPayload{Number: number})
+
+		return nil
+	}
+
+	edit := &github.PullRequest{
+		State: convert.Pointer("closed"),
+	}
+	pullRequest, response, err := r.client.PullRequests.Edit(r.ctx, owner, repo, number, edit)
+	r.audit(PlanActionClosePullRequest, owner, repo, edit, int64(number), pullRequest.GetHTMLURL(), err)
+	if err != nil {
+		return fmt.Errorf("failed to close pull request %d for %s/%s: %w", number, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to close pull request %d for %s/%s: %s", number, owner, repo, response.Status)
+	}
+	return nil
+}
+
+func (r *GithubImpl) CompareCommits(owner, repo, base, head string) ([]*github.RepositoryCommit, error) {
+	var commits []*github.RepositoryCommit
+
+	opts := &github.ListOptions{Page: 1, PerPage: 100}
+	for {
+		comparison, response, err := r.client.Repositories.CompareCommits(r.ctx, owner, repo, base, head, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s...%s for %s/%s: %w", base, head, owner, repo, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to compare %s...%s for %s/%s: %s", base, head, owner, repo, response.Status)
+		}
+
+		commits = append(commits, comparison.Commits...)
+		if response.NextPage == 0 {
+			return commits, nil
+		}
+
+		opts.Page = response.NextPage
+	}
+}
+
 func (r *GithubImpl) CreatePullRequest(owner, repo string, pr *github.NewPullRequest) (*github.PullRequest, error) {
 	if !r.real {
 		color.Yellow().Println(fmt.Sprintf("Preview mode, skip creating pull request for %s/%s", owner, repo))
+		r.record(PlanActionCreatePullRequest, owner, repo, pr)
+
 		return &github.PullRequest{
 			Title:   pr.Title,
-			HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/pull/fake", owner, repo)),
+			HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/compare/%s...%s", owner, repo, pr.GetBase(), pr.GetHead())),
 		}, nil
 	}
 
 	pullRequest, response, err := r.client.PullRequests.Create(r.ctx, owner, repo, pr)
+	r.audit(PlanActionCreatePullRequest, owner, repo, pr, int64(pullRequest.GetNumber()), pullRequest.GetHTMLURL(), err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request for %s/%s: %w", owner, repo, err)
 	}
@@ -96,10 +192,13 @@ func (r *GithubImpl) CreatePullRequest(owner, repo string, pr *github.NewPullReq
 func (r *GithubImpl) CreateRelease(owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error) {
 	if !r.real {
 		color.Yellow().Println(fmt.Sprintf("Preview mode, skip creating release for %s/%s", owner, repo))
+		r.record(PlanActionCreateRelease, owner, repo, release)
+
 		return nil, nil
 	}
 
 	createdRelease, response, err := r.client.Repositories.CreateRelease(r.ctx, owner, repo, release)
+	r.audit(PlanActionCreateRelease, owner, repo, release, createdRelease.GetID(), createdRelease.GetHTMLURL(), err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create release for %s/%s: %w", owner, repo, err)
 	}
@@ -109,6 +208,63 @@ func (r *GithubImpl) CreateRelease(owner, repo string, release *github.Repositor
 	return createdRelease, nil
 }
 
+func (r *GithubImpl) DeleteBranch(owner, repo, branch string) error {
+	if !r.real {
+		color.Yellow().Println(fmt.Sprintf("Preview mode, skip deleting branch %s for %s/%s", branch, owner, repo))
+		r.record(PlanActionDeleteBranch, owner, repo, &DeleteBranchPayload{Branch: branch})
+
+		return nil
+	}
+
+	response, err := r.client.Git.DeleteRef(r.ctx, owner, repo, "heads/"+branch)
+	r.audit(PlanActionDeleteBranch, owner, repo, &DeleteBranchPayload{Branch: branch}, 0, "", err)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s for %s/%s: %w", branch, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete branch %s for %s/%s: %s", branch, owner, repo, response.Status)
+	}
+	return nil
+}
+
+func (r *GithubImpl) DeleteRelease(owner, repo string, id int64) error {
+	if !r.real {
+		color.Yellow().Println(fmt.Sprintf("Preview mode, skip deleting release %d for %s/%s", id, owner, repo))
+		r.record(PlanActionDeleteRelease, owner, repo, &DeleteReleasePayload{ID: id})
+
+		return nil
+	}
+
+	response, err := r.client.Repositories.DeleteRelease(r.ctx, owner, repo, id)
+	r.audit(PlanActionDeleteRelease, owner, repo, &DeleteReleasePayload{ID: id}, id, "", err)
+	if err != nil {
+		return fmt.Errorf("failed to delete release %d for %s/%s: %w", id, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete release %d for %s/%s: %s", id, owner, repo, response.Status)
+	}
+	return nil
+}
+
+func (r *GithubImpl) DeleteTag(owner, repo, tag string) error {
+	if !r.real {
+		color.Yellow().Println(fmt.Sprintf("Preview mode, skip deleting tag %s for %s/%s", tag, owner, repo))
+		r.record(PlanActionDeleteTag, owner, repo, &DeleteTagPayload{Tag: tag})
+
+		return nil
+	}
+
+	response, err := r.client.Git.DeleteRef(r.ctx, owner, repo, "tags/"+tag)
+	r.audit(PlanActionDeleteTag, owner, repo, &DeleteTagPayload{Tag: tag}, 0, "", err)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag %s for %s/%s: %w", tag, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete tag %s for %s/%s: %s", tag, owner, repo, response.Status)
+	}
+	return nil
+}
+
 func (r *GithubImpl) GenerateReleaseNotes(owner, repo string, opts *github.GenerateNotesOptions) (*github.RepositoryReleaseNotes, error) {
 	notes, response, err := r.client.Repositories.GenerateReleaseNotes(r.ctx, owner, repo, opts)
 	if err != nil {
@@ -123,48 +279,100 @@ func (r *GithubImpl) GenerateReleaseNotes(owner, repo string, opts *github.Gener
 	return notes, nil
 }
 
-func (r *GithubImpl) GetLatestRelease(owner, repo, tag string) (*github.RepositoryRelease, error) {
-	releases, response, err := r.client.Repositories.ListReleases(r.ctx, owner, repo, &github.ListOptions{Page: 1, PerPage: 50})
-	if err != nil {
-		var apiErr *github.ErrorResponse
-		if errors.As(err, &apiErr) {
-			if apiErr.Response.StatusCode == http.StatusNotFound {
-				return nil, nil
-			}
+func (r *GithubImpl) GetCheckRuns(owner, repo, ref string) ([]*github.CheckRun, error) {
+	var checkRuns []*github.CheckRun
+
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{Page: 1, PerPage: 100}}
+	for {
+		results, response, err := r.client.Checks.ListCheckRunsForRef(r.ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs of %s for %s/%s: %w", ref, owner, repo, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list check runs of %s for %s/%s: %s", ref, owner, repo, response.Status)
 		}
 
-		return nil, fmt.Errorf("failed to get latest release for %s/%s: %w", owner, repo, err)
+		checkRuns = append(checkRuns, results.CheckRuns...)
+		if response.NextPage == 0 {
+			return checkRuns, nil
+		}
+
+		opts.Page = response.NextPage
 	}
-	if response.StatusCode == http.StatusNotFound {
-		return nil, nil
+}
+
+func (r *GithubImpl) GetCombinedStatus(owner, repo, ref string) (*github.CombinedStatus, error) {
+	var combined *github.CombinedStatus
+
+	opts := &github.ListOptions{Page: 1, PerPage: 100}
+	for {
+		status, response, err := r.client.Repositories.GetCombinedStatus(r.ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status of %s for %s/%s: %w", ref, owner, repo, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to get combined status of %s for %s/%s: %s", ref, owner, repo, response.Status)
+		}
+
+		if combined == nil {
+			combined = status
+		} else {
+			combined.Statuses = append(combined.Statuses, status.Statuses...)
+		}
+		if response.NextPage == 0 {
+			return combined, nil
+		}
+
+		opts.Page = response.NextPage
+	}
+}
+
+func (r *GithubImpl) GetDefaultBranch(owner, repo string) (string, error) {
+	repository, response, err := r.client.Repositories.Get(r.ctx, owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch for %s/%s: %w", owner, repo, err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get latest release for %s/%s: %s", owner, repo, response.Status)
+		return "", fmt.Errorf("failed to get default branch for %s/%s: %s", owner, repo, response.Status)
 	}
+	return repository.GetDefaultBranch(), nil
+}
 
-	if len(releases) == 0 {
-		return nil, nil
+func (r *GithubImpl) GetLatestRelease(owner, repo, tag string, includePrerelease bool) (*LatestRelease, error) {
+	var version *Version
+	if tag != "" {
+		parsed, err := ParseVersion(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		version = parsed
 	}
 
-	// v1.16.2 -> v1.16.
-	tagPrefix := strings.Join(strings.Split(tag, ".")[:2], ".") + "."
-	for _, release := range releases {
-		if strings.HasPrefix(release.GetTagName(), tagPrefix) {
-			return release, nil
-		}
+	var releases []*github.RepositoryRelease
+	if err := r.listAll(func(opts *github.ListOptions) (*github.Response, error) {
+		page, response, err := r.client.Repositories.ListReleases(r.ctx, owner, repo, opts)
+		releases = append(releases, page...)
+
+		return response, err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get latest release for %s/%s: %w", owner, repo, err)
 	}
 
-	return releases[0], nil
-}
+	var tags []*github.RepositoryTag
+	if err := r.listAll(func(opts *github.ListOptions) (*github.Response, error) {
+		page, response, err := r.client.Repositories.ListTags(r.ctx, owner, repo, opts)
+		tags = append(tags, page...)
 
-func (r *GithubImpl) GetPullRequest(owner, repo string, number int) (*github.PullRequest, error) {
-	if !r.real {
-		color.Yellow().Println(fmt.Sprintf("Preview mode, skip getting pull request %d for %s/%s", number, owner, repo))
-		return &github.PullRequest{
-			Merged: convert.Pointer(true),
-		}, nil
+		return response, err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get latest tag for %s/%s: %w", owner, repo, err)
 	}
 
+	return resolveLatestRelease(version, includePrerelease, releases, tags), nil
+}
+
+func (r *GithubImpl) GetPullRequest(owner, repo string, number int) (*github.PullRequest, error) {
 	pr, response, err := r.client.PullRequests.Get(r.ctx, owner, repo, number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull request %d for %s/%s: %w", number, owner, repo, err)
@@ -175,12 +383,29 @@ func (r *GithubImpl) GetPullRequest(owner, repo string, number int) (*github.Pul
 	return pr, nil
 }
 
-func (r *GithubImpl) GetPullRequests(owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
-	if !r.real {
-		color.Yellow().Println(fmt.Sprintf("Preview mode, skip listing pull requests for %s/%s", owner, repo))
-		return nil, nil
+func (r *GithubImpl) GetPullRequestFiles(owner, repo string, number int) ([]*github.CommitFile, error) {
+	var files []*github.CommitFile
+
+	opts := &github.ListOptions{Page: 1, PerPage: 100}
+	for {
+		pageFiles, response, err := r.client.PullRequests.ListFiles(r.ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of pull request %d for %s/%s: %w", number, owner, repo, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list files of pull request %d for %s/%s: %s", number, owner, repo, response.Status)
+		}
+
+		files = append(files, pageFiles...)
+		if response.NextPage == 0 {
+			return files, nil
+		}
+
+		opts.Page = response.NextPage
 	}
+}
 
+func (r *GithubImpl) GetPullRequests(owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
 	prs, response, err := r.client.PullRequests.List(r.ctx, owner, repo, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests for %s/%s: %w", owner, repo, err)
@@ -191,6 +416,20 @@ func (r *GithubImpl) GetPullRequests(owner, repo string, opts *github.PullReques
 	return prs, nil
 }
 
+func (r *GithubImpl) GetReleaseByTag(owner, repo, tag string) (*github.RepositoryRelease, error) {
+	release, response, err := r.client.Repositories.GetReleaseByTag(r.ctx, owner, repo, tag)
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release %s for %s/%s: %w", tag, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get release %s for %s/%s: %s", tag, owner, repo, response.Status)
+	}
+	return release, nil
+}
+
 func (r *GithubImpl) GetReleases(owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, error) {
 	releases, response, err := r.client.Repositories.ListReleases(r.ctx, owner, repo, opts)
 	if err != nil {
@@ -202,15 +441,62 @@ func (r *GithubImpl) GetReleases(owner, repo string, opts *github.ListOptions) (
 	return releases, nil
 }
 
+func (r *GithubImpl) GetRequiredStatusChecks(owner, repo, branch string) ([]string, error) {
+	checks, response, err := r.client.Repositories.GetRequiredStatusChecks(r.ctx, owner, repo, branch)
+	if errors.Is(err, github.ErrBranchNotProtected) || (response != nil && response.StatusCode == http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required status checks of %s for %s/%s: %w", branch, owner, repo, err)
+	}
+
+	var names []string
+	for _, check := range checks.GetChecks() {
+		names = append(names, check.Context)
+	}
+	for _, context := range checks.GetContexts() {
+		if !slices.Contains(names, context) {
+			names = append(names, context)
+		}
+	}
+
+	return names, nil
+}
+
+func (r *GithubImpl) MergePullRequest(owner, repo string, number int, method string) error {
+	if !r.real {
+		color.Yellow().Println(fmt.Sprintf("Preview mode, skip merging pull request %d for %s/%s", number, owner, repo))
+		r.record(PlanActionMergePullRequest, owner, repo, &MergePullRequestPayload{Number: number, Method: method})
+
+		return nil
+	}
+
+	result, response, err := r.client.PullRequests.Merge(r.ctx, owner, repo, number, "", &github.PullRequestOptions{MergeMethod: method})
+	r.audit(PlanActionMergePullRequest, owner, repo, &MergePullRequestPayload{Number: number, Method: method}, int64(number), "", err)
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request %d for %s/%s: %w", number, owner, repo, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to merge pull request %d for %s/%s: %s", number, owner, repo, response.Status)
+	}
+	if !result.GetMerged() {
+		return fmt.Errorf("failed to merge pull request %d for %s/%s: %s", number, owner, repo, result.GetMessage())
+	}
+	return nil
+}
+
 func (r *GithubImpl) SetDefaultBranch(owner, repo, branch string) error {
 	if !r.real {
 		color.Yellow().Println(fmt.Sprintf("Preview mode, skip setting default branch for %s/%s to %s", owner, repo, branch))
+		r.record(PlanActionSetDefaultBranch, owner, repo, &SetDefaultBranchPayload{Branch: branch})
+
 		return nil
 	}
 
-	_, response, err := r.client.Repositories.Edit(r.ctx, owner, repo, &github.Repository{
+	edited, response, err := r.client.Repositories.Edit(r.ctx, owner, repo, &github.Repository{
 		DefaultBranch: convert.Pointer(branch),
 	})
+	r.audit(PlanActionSetDefaultBranch, owner, repo, &SetDefaultBranchPayload{Branch: branch}, 0, edited.GetHTMLURL(), err)
 	if err != nil {
 		return fmt.Errorf("failed to set default branch for %s/%s: %w", owner, repo, err)
 	}
@@ -219,3 +505,137 @@ func (r *GithubImpl) SetDefaultBranch(owner, repo, branch string) error {
 	}
 	return nil
 }
+
+// listAll requests all pages of a list API, a repo that doesn't exist is treated as an empty list.
+func (r *GithubImpl) listAll(list func(opts *github.ListOptions) (*github.Response, error)) error {
+	opts := &github.ListOptions{Page: 1, PerPage: 100}
+	for {
+		response, err := list(opts)
+		if err != nil {
+			var apiErr *github.ErrorResponse
+			if errors.As(err, &apiErr) && apiErr.Response != nil && apiErr.Response.StatusCode == http.StatusNotFound {
+				return nil
+			}
+
+			return err
+		}
+		if response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		if response.StatusCode != http.StatusOK {
+			return errors.New(response.Status)
+		}
+		if response.NextPage == 0 {
+			return nil
+		}
+
+		opts.Page = response.NextPage
+	}
+}
+
+// audit appends the action sent to GitHub to the audit log, a failure to write is printed instead of returned
+// given the action has been performed anyway.
+func (r *GithubImpl) audit(actionType PlanActionType, owner, repo string, payload any, id int64, url string, actionErr error) {
+	if r.auditLog == nil {
+		return
+	}
+
+	if err := r.auditLog.Append(actionType, owner, repo, payload, id, url, actionErr); err != nil {
+		color.Red().Println(err.Error())
+	}
+}
+
+func (r *GithubImpl) record(actionType PlanActionType, owner, repo string, payload any) {
+	if r.plan != nil {
+		r.plan.Add(actionType, owner, repo, payload)
+	}
+}
+
+// LatestRelease is the previous release resolved for the tag to release, the release notes are generated against it.
+type LatestRelease struct {
+	// The highest tag strictly lower than the tag to release
+	Tag string
+	// The GitHub release of the tag, nil if the tag is only pushed as a git tag
+	Release *github.RepositoryRelease
+	// How the tag is resolved, it's empty unless some releases or tags are skipped
+	Decisions []string
+}
+
+// resolveLatestRelease chooses the highest version strictly lower than the version among the releases and tags.
+// The drafts are skipped, and so are the prereleases unless the version is a prerelease itself.
+func resolveLatestRelease(version *Version, includePrerelease bool, releases []*github.RepositoryRelease, tags []*github.RepositoryTag) *LatestRelease {
+	var (
+		latest        *LatestRelease
+		latestVersion *Version
+		seen          = make(map[string]bool)
+		skipped       = make(map[string][]string)
+		reasons       []string
+	)
+
+	skip := func(reason, name string) {
+		if _, exist := skipped[reason]; !exist {
+			reasons = append(reasons, reason)
+		}
+		skipped[reason] = append(skipped[reason], name)
+	}
+
+	consider := func(name string, prerelease bool, release *github.RepositoryRelease) {
+		candidate, err := ParseVersion(name)
+		if err != nil {
+			skip("skipped the tags that are not semantic versions", name)
+			return
+		}
+		if !includePrerelease && (prerelease || candidate.Prerelease != "") {
+			skip("skipped the prereleases", name)
+			return
+		}
+		if version != nil && candidate.Compare(version) >= 0 {
+			skip(fmt.Sprintf("skipped the versions not lower than %s", version), name)
+			return
+		}
+
+		if latestVersion == nil || candidate.Compare(latestVersion) > 0 {
+			latest = &LatestRelease{Tag: name, Release: release}
+			latestVersion = candidate
+		}
+	}
+
+	for _, release := range releases {
+		name := release.GetTagName()
+		seen[name] = true
+
+		if release.GetDraft() {
+			skip("skipped the drafts", name)
+			continue
+		}
+
+		consider(name, release.GetPrerelease(), release)
+	}
+
+	for _, tag := range tags {
+		if name := tag.GetName(); !seen[name] {
+			seen[name] = true
+			consider(name, false, nil)
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	for _, reason := range reasons {
+		latest.Decisions = append(latest.Decisions, fmt.Sprintf("%s: %s", reason, strings.Join(skipped[reason], ", ")))
+	}
+	if latest.Release == nil {
+		latest.Decisions = append(latest.Decisions, fmt.Sprintf("%s has no GitHub release, it's resolved from the git tags", latest.Tag))
+	}
+	if len(latest.Decisions) > 0 && version == nil && includePrerelease {
+		latest.Decisions = append(latest.Decisions, fmt.Sprintf("chose %s, the highest version", latest.Tag))
+	} else if len(latest.Decisions) > 0 && version == nil {
+		latest.Decisions = append(latest.Decisions, fmt.Sprintf("chose %s, the highest final version", latest.Tag))
+	} else if len(latest.Decisions) > 0 {
+		latest.Decisions = append(latest.Decisions, fmt.Sprintf("chose %s, the highest version lower than %s", latest.Tag, version))
+	}
+
+	return latest
+}
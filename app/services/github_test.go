@@ -0,0 +1,419 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/goravel/framework/support/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/testing/fakegithub"
+)
+
+func TestResolveLatestRelease(t *testing.T) {
+	release := func(tag string, draft, prerelease bool) *github.RepositoryRelease {
+		return &github.RepositoryRelease{
+			TagName:    convert.Pointer(tag),
+			Draft:      convert.Pointer(draft),
+			Prerelease: convert.Pointer(prerelease),
+		}
+	}
+	tag := func(name string) *github.RepositoryTag {
+		return &github.RepositoryTag{Name: convert.Pointer(name)}
+	}
+
+	// In the order GitHub returns them, the newest first
+	releases := []*github.RepositoryRelease{
+		release("v1.17.1", true, false),
+		release("v1.17.0", false, false),
+		release("v1.17.0-rc.2", false, true),
+		release("v1.17.0-rc.1", false, true),
+		release("v1.16.2", false, false),
+		release("v1.16.1", false, false),
+		release("v1.16.0", false, false),
+	}
+	tags := []*github.RepositoryTag{
+		tag("v1.17.0"),
+		tag("v1.16.3"),
+		tag("v1.16.2"),
+		tag("latest"),
+	}
+
+	tests := []struct {
+		name              string
+		tag               string
+		includePrerelease bool
+		releases          []*github.RepositoryRelease
+		tags              []*github.RepositoryTag
+		want              *LatestRelease
+	}{
+		{
+			name:     "patch on the latest line",
+			tag:      "v1.17.1",
+			releases: releases,
+			want: &LatestRelease{
+				Tag:     "v1.17.0",
+				Release: releases[1],
+				Decisions: []string{
+					"skipped the drafts: v1.17.1",
+					"skipped the prereleases: v1.17.0-rc.2, v1.17.0-rc.1",
+					"chose v1.17.0, the highest version lower than v1.17.1",
+				},
+			},
+		},
+		{
+			name:     "patch on an old line",
+			tag:      "v1.16.3",
+			releases: releases[1:],
+			want: &LatestRelease{
+				Tag:     "v1.16.2",
+				Release: releases[4],
+				Decisions: []string{
+					"skipped the versions not lower than v1.16.3: v1.17.0",
+					"skipped the prereleases: v1.17.0-rc.2, v1.17.0-rc.1",
+					"chose v1.16.2, the highest version lower than v1.16.3",
+				},
+			},
+		},
+		{
+			name:     "tag without release",
+			tag:      "v1.16.4",
+			releases: releases[4:],
+			tags:     tags,
+			want: &LatestRelease{
+				Tag: "v1.16.3",
+				Decisions: []string{
+					"skipped the versions not lower than v1.16.4: v1.17.0",
+					"skipped the tags that are not semantic versions: latest",
+					"v1.16.3 has no GitHub release, it's resolved from the git tags",
+					"chose v1.16.3, the highest version lower than v1.16.4",
+				},
+			},
+		},
+		{
+			name:     "first major",
+			tag:      "v1.16.0",
+			releases: releases[6:],
+			want:     nil,
+		},
+		{
+			name:     "latest final release without tag",
+			releases: releases,
+			tags:     tags,
+			want: &LatestRelease{
+				Tag:     "v1.17.0",
+				Release: releases[1],
+				Decisions: []string{
+					"skipped the drafts: v1.17.1",
+					"skipped the prereleases: v1.17.0-rc.2, v1.17.0-rc.1",
+					"skipped the tags that are not semantic versions: latest",
+					"chose v1.17.0, the highest final version",
+				},
+			},
+		},
+		{
+			name:     "unambiguous",
+			tag:      "v1.16.3",
+			releases: releases[4:],
+			want: &LatestRelease{
+				Tag:     "v1.16.2",
+				Release: releases[4],
+			},
+		},
+		{
+			name:              "prerelease against the last prerelease",
+			tag:               "v1.17.0-rc.3",
+			includePrerelease: true,
+			releases:          releases[2:],
+			want: &LatestRelease{
+				Tag:     "v1.17.0-rc.2",
+				Release: releases[2],
+			},
+		},
+		{
+			name:              "first prerelease against the last final release",
+			tag:               "v1.17.0-rc.1",
+			includePrerelease: true,
+			releases:          releases[3:],
+			want: &LatestRelease{
+				Tag:     "v1.16.2",
+				Release: releases[4],
+				Decisions: []string{
+					"skipped the versions not lower than v1.17.0-rc.1: v1.17.0-rc.1",
+					"chose v1.16.2, the highest version lower than v1.17.0-rc.1",
+				},
+			},
+		},
+		{
+			name:     "prereleases skipped unless asked",
+			tag:      "v1.17.0-rc.3",
+			releases: releases[2:],
+			want: &LatestRelease{
+				Tag:     "v1.16.2",
+				Release: releases[4],
+				Decisions: []string{
+					"skipped the prereleases: v1.17.0-rc.2, v1.17.0-rc.1",
+					"chose v1.16.2, the highest version lower than v1.17.0-rc.3",
+				},
+			},
+		},
+		{
+			name:              "latest release including the prereleases",
+			includePrerelease: true,
+			releases:          releases,
+			want: &LatestRelease{
+				Tag:     "v1.17.0",
+				Release: releases[1],
+				Decisions: []string{
+					"skipped the drafts: v1.17.1",
+					"chose v1.17.0, the highest version",
+				},
+			},
+		},
+		{
+			name:              "latest prerelease",
+			includePrerelease: true,
+			releases:          releases[2:],
+			want: &LatestRelease{
+				Tag:     "v1.17.0-rc.2",
+				Release: releases[2],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var version *Version
+			if tt.tag != "" {
+				parsed, err := ParseVersion(tt.tag)
+				require.NoError(t, err)
+
+				version = parsed
+			}
+
+			assert.Equal(t, tt.want, resolveLatestRelease(version, tt.includePrerelease, tt.releases, tt.tags))
+		})
+	}
+}
+
+func TestGithubImpl(t *testing.T) {
+	server := fakegithub.NewServer()
+	defer server.Close()
+
+	server.AddRepo("goravel", "framework", "v1.16.x")
+	for patch := range 150 {
+		server.AddRelease("goravel", "framework", fmt.Sprintf("v1.16.%d", patch), false)
+	}
+	server.AddRelease("goravel", "framework", "v1.17.0-rc.1", true)
+
+	githubImpl := newGithubImpl("token", server.URL, true, nil, nil)
+
+	t.Run("GetLatestRelease pages all releases", func(t *testing.T) {
+		latest, err := githubImpl.GetLatestRelease("goravel", "framework", "v1.17.0", false)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.149", latest.Tag)
+
+		latest, err = githubImpl.GetLatestRelease("goravel", "framework", "v1.17.0", true)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.17.0-rc.1", latest.Tag)
+
+		latest, err = githubImpl.GetLatestRelease("goravel", "framework", "v1.16.1", false)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.0", latest.Tag)
+
+		latest, err = githubImpl.GetLatestRelease("goravel", "framework", "", false)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.149", latest.Tag)
+
+		latest, err = githubImpl.GetLatestRelease("goravel", "framework", "", true)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.17.0-rc.1", latest.Tag)
+
+		latest, err = githubImpl.GetLatestRelease("goravel", "gin", "v1.17.0", false)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+
+	t.Run("CheckBranchExists", func(t *testing.T) {
+		exists, err := githubImpl.CheckBranchExists("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = githubImpl.CheckBranchExists("goravel", "framework", "v1.17.x")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("pull requests", func(t *testing.T) {
+		pr, err := githubImpl.CreatePullRequest("goravel", "framework", &github.NewPullRequest{
+			Title: convert.Pointer("chore: Update version to v1.17.0 (auto)"),
+			Head:  convert.Pointer("v1.16.x"),
+			Base:  convert.Pointer("master"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, pr.GetNumber())
+
+		prs, err := githubImpl.GetPullRequests("goravel", "framework", &github.PullRequestListOptions{State: "open"})
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "chore: Update version to v1.17.0 (auto)", prs[0].GetTitle())
+
+		server.MergePull("goravel", "framework", 1)
+
+		pr, err = githubImpl.GetPullRequest("goravel", "framework", 1)
+		require.NoError(t, err)
+		assert.True(t, pr.GetMerged())
+
+		number := server.AddPull("goravel", "framework", "chore: Upgrade framework to v1.16.150 (auto)", "auto-upgrade/v1.16.150", "v1.16.x")
+		require.NoError(t, githubImpl.MergePullRequest("goravel", "framework", number, "squash"))
+		assert.True(t, server.Repo("goravel", "framework").Pulls[number-1].GetMerged())
+		assert.Equal(t, []string{"squash"}, server.Repo("goravel", "framework").MergeMethods)
+
+		assert.ErrorContains(t, githubImpl.MergePullRequest("goravel", "framework", number, "squash"), "failed to merge pull request 2 for goravel/framework")
+
+		server.AddPullFile("goravel", "framework", number, "go.mod", 1, 1)
+		server.AddPullFile("goravel", "framework", number, "go.sum", 4, 4)
+		files, err := githubImpl.GetPullRequestFiles("goravel", "framework", number)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Equal(t, "go.sum", files[1].GetFilename())
+		assert.Equal(t, 4, files[1].GetAdditions())
+	})
+
+	t.Run("CompareCommits", func(t *testing.T) {
+		commits, err := githubImpl.CompareCommits("goravel", "framework", "v1.17.0-rc.1", "master")
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		assert.Equal(t, "chore: Update version to v1.17.0 (auto) (#1)", commits[0].GetCommit().GetMessage())
+
+		_, err = githubImpl.CompareCommits("goravel", "framework", "v1.15.0", "master")
+		assert.ErrorContains(t, err, "failed to compare v1.15.0...master for goravel/framework")
+	})
+
+	t.Run("releases", func(t *testing.T) {
+		notes, err := githubImpl.GenerateReleaseNotes("goravel", "framework", &github.GenerateNotesOptions{
+			TagName:         "v1.16.150",
+			PreviousTagName: convert.Pointer("v1.16.149"),
+			TargetCommitish: convert.Pointer("v1.16.x"),
+		})
+		require.NoError(t, err)
+		assert.Contains(t, notes.Body, "compare/v1.16.149...v1.16.150")
+
+		release, err := githubImpl.CreateRelease("goravel", "framework", &github.RepositoryRelease{
+			TagName:         convert.Pointer("v1.16.150"),
+			TargetCommitish: convert.Pointer("v1.16.x"),
+			Name:            convert.Pointer(notes.Name),
+			Body:            convert.Pointer(notes.Body),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.150", release.GetTagName())
+
+		_, err = githubImpl.CreateRelease("goravel", "framework", release)
+		assert.ErrorContains(t, err, "failed to create release for goravel/framework")
+
+		releases, err := githubImpl.GetReleases("goravel", "framework", &github.ListOptions{PerPage: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.150", releases[0].GetTagName())
+
+		release, err = githubImpl.GetReleaseByTag("goravel", "framework", "v1.16.150")
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.150", release.GetTagName())
+
+		release, err = githubImpl.GetReleaseByTag("goravel", "framework", "v1.16.151")
+		require.NoError(t, err)
+		assert.Nil(t, release)
+	})
+
+	t.Run("checks", func(t *testing.T) {
+		required, err := githubImpl.GetRequiredStatusChecks("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		assert.Nil(t, required)
+
+		server.SetRequiredChecks("goravel", "framework", "v1.16.x", "test", "codecov/patch")
+		server.AddStatus("goravel", "framework", "v1.16.x", "codecov/patch", "success")
+		server.AddCheckRun("goravel", "framework", "v1.16.x", "test", "completed", "failure")
+
+		required, err = githubImpl.GetRequiredStatusChecks("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"test", "codecov/patch"}, required)
+
+		status, err := githubImpl.GetCombinedStatus("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		require.Len(t, status.Statuses, 1)
+		assert.Equal(t, "success", status.Statuses[0].GetState())
+
+		checkRuns, err := githubImpl.GetCheckRuns("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		require.Len(t, checkRuns, 1)
+		assert.Equal(t, "test", checkRuns[0].GetName())
+		assert.Equal(t, "failure", checkRuns[0].GetConclusion())
+	})
+
+	t.Run("SetDefaultBranch", func(t *testing.T) {
+		require.NoError(t, githubImpl.SetDefaultBranch("goravel", "framework", "v1.16.x"))
+		assert.Equal(t, "v1.16.x", server.Repo("goravel", "framework").DefaultBranch)
+
+		assert.ErrorContains(t, githubImpl.SetDefaultBranch("goravel", "framework", "v1.17.x"), "failed to set default branch for goravel/framework")
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		branch, err := githubImpl.GetDefaultBranch("goravel", "framework")
+		require.NoError(t, err)
+		assert.Equal(t, "v1.16.x", branch)
+
+		number := server.AddPull("goravel", "framework", "chore: Upgrade framework to v1.16.151 (auto)", "auto-upgrade/v1.16.151", "v1.16.x")
+		require.NoError(t, githubImpl.ClosePullRequest("goravel", "framework", number))
+		assert.Equal(t, "closed", server.Repo("goravel", "framework").Pulls[number-1].GetState())
+		assert.False(t, server.Repo("goravel", "framework").Pulls[number-1].GetMerged())
+
+		releases, err := githubImpl.GetReleases("goravel", "framework", &github.ListOptions{PerPage: 1})
+		require.NoError(t, err)
+		require.NoError(t, githubImpl.DeleteRelease("goravel", "framework", releases[0].GetID()))
+		assert.ErrorContains(t, githubImpl.DeleteRelease("goravel", "framework", releases[0].GetID()), fmt.Sprintf("failed to delete release %d for goravel/framework", releases[0].GetID()))
+		assert.Contains(t, server.Repo("goravel", "framework").Tags, "v1.16.150")
+
+		require.NoError(t, githubImpl.DeleteTag("goravel", "framework", "v1.16.150"))
+		assert.NotContains(t, server.Repo("goravel", "framework").Tags, "v1.16.150")
+		assert.ErrorContains(t, githubImpl.DeleteTag("goravel", "framework", "v1.16.150"), "failed to delete tag v1.16.150 for goravel/framework")
+
+		require.NoError(t, githubImpl.SetDefaultBranch("goravel", "framework", "master"))
+		require.NoError(t, githubImpl.DeleteBranch("goravel", "framework", "v1.16.x"))
+		exists, err := githubImpl.CheckBranchExists("goravel", "framework", "v1.16.x")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		auditLog := NewAuditLog(path, "goravel")
+		auditLog.SetRelease("major", "v1.17.0")
+		audited := newGithubImpl("token", server.URL, true, nil, auditLog)
+
+		pr, err := audited.CreatePullRequest("goravel", "framework", &github.NewPullRequest{
+			Title: convert.Pointer("chore: Update version to v1.17.0 (auto)"),
+			Head:  convert.Pointer("auto-version/v1.17.0"),
+			Base:  convert.Pointer("master"),
+		})
+		require.NoError(t, err)
+		assert.Error(t, audited.SetDefaultBranch("goravel", "framework", "v1.17.x"))
+		_, err = audited.GetPullRequest("goravel", "framework", pr.GetNumber())
+		require.NoError(t, err)
+
+		entries := readAuditLog(t, path)
+		require.Len(t, entries, 2)
+		assert.Equal(t, PlanActionCreatePullRequest, entries[0].Action)
+		assert.Equal(t, "auto-version/v1.17.0", entries[0].Payload.(map[string]any)["head"])
+		assert.Equal(t, int64(pr.GetNumber()), entries[0].ID)
+		assert.Equal(t, pr.GetHTMLURL(), entries[0].URL)
+		assert.Equal(t, PlanActionSetDefaultBranch, entries[1].Action)
+		assert.Contains(t, entries[1].Error, "422")
+
+		// Nothing is sent in preview mode, so nothing is audited.
+		preview := newGithubImpl("token", server.URL, false, NewPlan(), auditLog)
+		require.NoError(t, preview.SetDefaultBranch("goravel", "framework", "master"))
+		assert.Len(t, readAuditLog(t, path), 2)
+	})
+}
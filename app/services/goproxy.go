@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const (
+	DefaultGoProxyURL = "https://proxy.golang.org/"
+	DefaultSumDBURL   = "https://sum.golang.org/"
+)
+
+// ErrChecksumMismatch is returned by Verify when the hash of the module served by the proxy differs from the
+// checksum database, it won't be fixed by retrying.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type GoProxy interface {
+	// Verify fetches the .info, .mod and .zip of the module version from the Go module proxy, then checks the
+	// hashes of the zip and the go.mod against the go.sum lines of the checksum database.
+	Verify(path, version string) error
+}
+
+type GoProxyImpl struct {
+	client   *http.Client
+	proxyURL string
+	sumDBURL string
+}
+
+// NewGoProxyImpl creates the client of the Go module proxy and the checksum database, the empty URLs default to
+// proxy.golang.org and sum.golang.org.
+func NewGoProxyImpl(proxyURL, sumDBURL string) *GoProxyImpl {
+	if proxyURL == "" {
+		proxyURL = DefaultGoProxyURL
+	}
+	if sumDBURL == "" {
+		sumDBURL = DefaultSumDBURL
+	}
+
+	return &GoProxyImpl{
+		client:   &http.Client{Timeout: 5 * time.Minute},
+		proxyURL: strings.TrimSuffix(proxyURL, "/"),
+		sumDBURL: strings.TrimSuffix(sumDBURL, "/"),
+	}
+}
+
+func (r *GoProxyImpl) Verify(path, version string) error {
+	escapedPath, err := module.EscapePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid module path %s: %w", path, err)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return fmt.Errorf("invalid module version %s: %w", version, err)
+	}
+
+	prefix := fmt.Sprintf("%s/%s/@v/%s", r.proxyURL, escapedPath, escapedVersion)
+
+	info, err := r.get(prefix + ".info")
+	if err != nil {
+		return err
+	}
+	var origin struct {
+		Version string
+	}
+	if err := json.Unmarshal(info, &origin); err != nil {
+		return fmt.Errorf("failed to parse %s.info: %w", prefix, err)
+	}
+	if origin.Version != version {
+		return fmt.Errorf("%s.info resolves to %s, not %s", prefix, origin.Version, version)
+	}
+
+	goMod, err := r.get(prefix + ".mod")
+	if err != nil {
+		return err
+	}
+	goModHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(goMod)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash %s.mod: %w", prefix, err)
+	}
+
+	zipHash, err := r.hashZip(prefix + ".zip")
+	if err != nil {
+		return err
+	}
+
+	sums, err := r.lookup(escapedPath, escapedVersion, path, version)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range [][2]string{{version, zipHash}, {version + "/go.mod", goModHash}} {
+		name, hash := line[0], line[1]
+		sum, ok := sums[name]
+		if !ok {
+			return fmt.Errorf("%s %s is not in the checksum database", path, name)
+		}
+		if sum != hash {
+			return fmt.Errorf("%w: %s %s is %s in the proxy, but %s in the checksum database", ErrChecksumMismatch, path, name, hash, sum)
+		}
+	}
+
+	return nil
+}
+
+func (r *GoProxyImpl) get(url string) ([]byte, error) {
+	response, err := r.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: %d %s", url, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// hashZip downloads the module zip to a temp file, given the zip is read by its central directory.
+func (r *GoProxyImpl) hashZip(url string) (string, error) {
+	content, err := r.get(url)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "goravel-release-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file for %s: %w", url, err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err := file.Write(content); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", url, err)
+	}
+
+	hash, err := dirhash.HashZip(file.Name(), dirhash.Hash1)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", url, err)
+	}
+
+	return hash, nil
+}
+
+// lookup returns the go.sum lines of the module version recorded in the checksum database, keyed by the version,
+// e.g. v1.17.0 and v1.17.0/go.mod. The signed tree head following the lines isn't verified.
+func (r *GoProxyImpl) lookup(escapedPath, escapedVersion, path, version string) (map[string]string, error) {
+	body, err := r.get(fmt.Sprintf("%s/lookup/%s@%s", r.sumDBURL, escapedPath, escapedVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == path && strings.HasPrefix(fields[1], version) {
+			sums[fields[1]] = fields[2]
+		}
+	}
+
+	return sums, nil
+}
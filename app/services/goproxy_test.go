@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goravel/app/testing/gitfixture"
+)
+
+func TestGoProxyImpl(t *testing.T) {
+	fixtures := gitfixture.New(t)
+	fixtures.AddModule("github.com/goravel/framework", "v1.17.0", map[string]string{
+		"go.mod":                    "module github.com/goravel/framework\n\ngo 1.22\n",
+		"foundation/application.go": "package foundation\n\nfunc Boot() {}\n",
+	})
+	proxy := fixtures.ServeProxy()
+
+	t.Run("fetchable", func(t *testing.T) {
+		assert.NoError(t, NewGoProxyImpl(proxy.URL, proxy.URL).Verify("github.com/goravel/framework", "v1.17.0"))
+	})
+
+	t.Run("not fetchable yet", func(t *testing.T) {
+		err := NewGoProxyImpl(proxy.URL, proxy.URL).Verify("github.com/goravel/framework", "v1.17.1")
+
+		assert.ErrorContains(t, err, fmt.Sprintf("failed to get %s/github.com/goravel/framework/@v/v1.17.1.info: 404", proxy.URL))
+		assert.False(t, errors.Is(err, ErrChecksumMismatch))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		sumDB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = fmt.Fprint(w, "1\ngithub.com/goravel/framework v1.17.0 h1:tampered=\ngithub.com/goravel/framework v1.17.0/go.mod h1:tampered=\n")
+		}))
+		defer sumDB.Close()
+
+		err := NewGoProxyImpl(proxy.URL, sumDB.URL).Verify("github.com/goravel/framework", "v1.17.0")
+
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		assert.ErrorContains(t, err, "github.com/goravel/framework v1.17.0 is h1:")
+		assert.ErrorContains(t, err, "but h1:tampered= in the checksum database")
+	})
+
+	t.Run("not in the checksum database", func(t *testing.T) {
+		sumDB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = fmt.Fprint(w, "1\n\ngo.sum database tree\n")
+		}))
+		defer sumDB.Close()
+
+		err := NewGoProxyImpl(proxy.URL, sumDB.URL).Verify("github.com/goravel/framework", "v1.17.0")
+
+		assert.EqualError(t, err, "github.com/goravel/framework v1.17.0 is not in the checksum database")
+	})
+}
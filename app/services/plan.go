@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type PlanActionType string
+
+const (
+	PlanActionCheckStatus       PlanActionType = "check_status"
+	PlanActionClosePullRequest  PlanActionType = "close_pull_request"
+	PlanActionCreatePullRequest PlanActionType = "create_pull_request"
+	PlanActionCreateRelease     PlanActionType = "create_release"
+	PlanActionDeleteBranch      PlanActionType = "delete_branch"
+	PlanActionDeleteRelease     PlanActionType = "delete_release"
+	PlanActionDeleteTag         PlanActionType = "delete_tag"
+	PlanActionMergePullRequest  PlanActionType = "merge_pull_request"
+	PlanActionPushBranch        PlanActionType = "push_branch"
+	PlanActionSetDefaultBranch  PlanActionType = "set_default_branch"
+)
+
+type PlanAction struct {
+	// The action type
+	Type PlanActionType `json:"type"`
+	// The repo owner
+	Owner string `json:"owner"`
+	// The repo name
+	Repo string `json:"repo"`
+	// The exact payload the action would send, e.g. *github.NewPullRequest for create_pull_request
+	Payload any `json:"payload"`
+}
+
+type CheckStatusPayload struct {
+	// The branch the checks are reported at
+	Branch string `json:"branch"`
+	// The failing checks, they would block the release
+	Failing []string `json:"failing,omitempty"`
+	// The pending checks, the release would wait for them up to --wait-checks
+	Pending []string `json:"pending,omitempty"`
+}
+
+type ClosePullRequestPayload struct {
+	// The pull request number
+	Number int `json:"number"`
+}
+
+type DeleteBranchPayload struct {
+	// The branch to delete
+	Branch string `json:"branch"`
+}
+
+type DeleteReleasePayload struct {
+	// The release ID
+	ID int64 `json:"id"`
+}
+
+type DeleteTagPayload struct {
+	// The tag to delete
+	Tag string `json:"tag"`
+}
+
+type MergePullRequestPayload struct {
+	// The pull request number
+	Number int `json:"number"`
+	// The merge method: merge, squash or rebase
+	Method string `json:"method"`
+}
+
+type PushBranchPayload struct {
+	// The branch to push
+	Branch string `json:"branch"`
+	// The branch the pushed branch is created from
+	Base string `json:"base"`
+	// Whether to force push
+	Force bool `json:"force"`
+	// The changes committed to the branch, empty if the branch is pushed as is
+	DiffStat string `json:"diff_stat,omitempty"`
+	// The commands producing the changes, e.g. the go get upgrading the framework
+	Commands []string `json:"commands,omitempty"`
+}
+
+type SetDefaultBranchPayload struct {
+	// The new default branch
+	Branch string `json:"branch"`
+}
+
+// Plan records the mutating actions a release would take in preview mode, in order, so they can be
+// reviewed before the real release. The state of the checks gating each release is recorded as well.
+// It's safe for concurrent use given repos are upgraded concurrently.
+type Plan struct {
+	// The recorded actions, in order of recording
+	Actions []*PlanAction `json:"actions"`
+
+	mu sync.Mutex
+}
+
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+func (r *Plan) Add(actionType PlanActionType, owner, repo string, payload any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Actions = append(r.Actions, &PlanAction{
+		Type:    actionType,
+		Owner:   owner,
+		Repo:    repo,
+		Payload: payload,
+	})
+}
+
+func (r *Plan) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode release plan: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create release plan directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to save release plan %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionRegexp = regexp.MustCompile(`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?$`)
+
+// Version is a semantic version tag, e.g. v1.16.0 or v1.17.0-rc.1.
+type Version struct {
+	// The major version
+	Major int
+	// The minor version, it's the goravel "major" version, e.g. 16 of v1.16.0
+	Minor int
+	// The patch version
+	Patch int
+	// The prerelease identifiers, e.g. rc.1 of v1.17.0-rc.1
+	Prerelease string
+}
+
+func ParseVersion(tag string) (*Version, error) {
+	matches := versionRegexp.FindStringSubmatch(tag)
+	if matches == nil {
+		if !strings.HasPrefix(tag, "v") && versionRegexp.MatchString("v"+tag) {
+			return nil, fmt.Errorf("invalid tag %q, it should start with v, e.g. v%s", tag, tag)
+		}
+
+		return nil, fmt.Errorf("invalid tag %q, it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1", tag)
+	}
+
+	version := &Version{Prerelease: matches[4]}
+	for i, number := range []*int{&version.Major, &version.Minor, &version.Patch} {
+		value, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %q: %w", tag, err)
+		}
+
+		*number = value
+	}
+
+	return version, nil
+}
+
+// Branch returns the version branch, e.g. v1.16.x.
+func (r *Version) Branch() string {
+	return fmt.Sprintf("v%d.%d.x", r.Major, r.Minor)
+}
+
+// Compare returns -1, 0 or 1 if the version is lower than, equal to or higher than the other one,
+// following the semantic versioning precedence, e.g. v1.17.0-rc.1 < v1.17.0.
+func (r *Version) Compare(other *Version) int {
+	for _, diff := range []int{r.Major - other.Major, r.Minor - other.Minor, r.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	return comparePrerelease(r.Prerelease, other.Prerelease)
+}
+
+// IsMajor checks if the version starts a new version branch, e.g. v1.16.0, the minor version
+// is the goravel major version.
+func (r *Version) IsMajor() bool {
+	return r.Patch == 0 && r.Prerelease == ""
+}
+
+func (r *Version) String() string {
+	version := fmt.Sprintf("v%d.%d.%d", r.Major, r.Minor, r.Patch)
+	if r.Prerelease != "" {
+		version += "-" + r.Prerelease
+	}
+
+	return version
+}
+
+func comparePrerelease(a, b string) int {
+	// A version without prerelease has a higher precedence than the one with prerelease.
+	if a == "" || b == "" {
+		return sign(len(b) - len(a))
+	}
+
+	aIdentifiers, bIdentifiers := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.Atoi(aIdentifiers[i])
+		bNumber, bErr := strconv.Atoi(bIdentifiers[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return sign(aNumber - bNumber)
+			}
+		// The numeric identifiers have a lower precedence than the alphanumeric ones.
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if compared := strings.Compare(aIdentifiers[i], bIdentifiers[i]); compared != 0 {
+				return compared
+			}
+		}
+	}
+
+	return sign(len(aIdentifiers) - len(bIdentifiers))
+}
+
+func sign(number int) int {
+	switch {
+	case number < 0:
+		return -1
+	case number > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    *Version
+		wantErr string
+	}{
+		{tag: "v1.16.0", want: &Version{Major: 1, Minor: 16}},
+		{tag: "v1.16.2", want: &Version{Major: 1, Minor: 16, Patch: 2}},
+		{tag: "v1.17.0-rc.1", want: &Version{Major: 1, Minor: 17, Prerelease: "rc.1"}},
+		{tag: "v1.17", wantErr: `invalid tag "v1.17", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`},
+		{tag: "1.17.0", wantErr: `invalid tag "1.17.0", it should start with v, e.g. v1.17.0`},
+		{tag: "v1.017.0", wantErr: `invalid tag "v1.017.0", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`},
+		{tag: "v1.17.0-rc..1", wantErr: `invalid tag "v1.17.0-rc..1", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`},
+		{tag: "", wantErr: `invalid tag "", it should be a semantic version, e.g. v1.16.0 or v1.16.0-rc.1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			version, err := ParseVersion(tt.tag)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+			assert.Equal(t, tt.tag, version.String())
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	version, err := ParseVersion("v1.16.2")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.16.x", version.Branch())
+	assert.False(t, version.IsMajor())
+
+	major, err := ParseVersion("v1.17.0")
+	require.NoError(t, err)
+	assert.True(t, major.IsMajor())
+
+	rc, err := ParseVersion("v1.17.0-rc.1")
+	require.NoError(t, err)
+	assert.False(t, rc.IsMajor())
+	assert.Equal(t, "v1.17.x", rc.Branch())
+}
+
+func TestVersionCompare(t *testing.T) {
+	// In ascending order
+	tags := []string{
+		"v1.15.9",
+		"v1.16.0-alpha",
+		"v1.16.0-alpha.1",
+		"v1.16.0-alpha.beta",
+		"v1.16.0-beta.2",
+		"v1.16.0-beta.11",
+		"v1.16.0-rc.1",
+		"v1.16.0",
+		"v1.16.10",
+		"v2.0.0",
+	}
+
+	for i := range tags {
+		for j := range tags {
+			a, err := ParseVersion(tags[i])
+			require.NoError(t, err)
+			b, err := ParseVersion(tags[j])
+			require.NoError(t, err)
+
+			assert.Equal(t, sign(i-j), a.Compare(b), "%s compared to %s", tags[i], tags[j])
+		}
+	}
+}
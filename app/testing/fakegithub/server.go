@@ -0,0 +1,766 @@
+// Package fakegithub is an in-process stand-in for the GitHub REST API used by the release tool, it's backed by
+// in-memory state so the releases can be tested end to end offline.
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/goravel/framework/support/convert"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	repos map[string]*Repo
+}
+
+type Repo struct {
+	// The repo owner
+	Owner string
+	// The repo name
+	Name string
+	// The default branch
+	DefaultBranch string
+	// The existing branches
+	Branches []string
+	// The git tags, the newest first, the tags of the releases are included
+	Tags []string
+	// The releases, the newest first
+	Releases []*github.RepositoryRelease
+	// The pull requests, in order of creation
+	Pulls []*github.PullRequest
+	// The merge methods of the pull requests merged via the API, in order of merging
+	MergeMethods []string
+
+	// The commits, in order of creation
+	commits []*commit
+	// The number of commits when the tag is created, the tag points to the last of them
+	tagCommits map[string]int
+	// The commit statuses keyed by ref, e.g. a branch
+	statuses map[string][]*github.RepoStatus
+	// The check runs keyed by ref
+	checkRuns map[string][]*github.CheckRun
+	// The conclusions and the number of lists left of the check runs in progress, see AddPendingCheckRun
+	pendingCheckRuns map[*github.CheckRun]*pendingCheckRun
+	// The checks required by the branch protection keyed by branch
+	requiredChecks map[string][]string
+	// The files changed by the pull requests keyed by number
+	pullFiles map[int][]*github.CommitFile
+}
+
+type pendingCheckRun struct {
+	conclusion string
+	polls      int
+}
+
+type commit struct {
+	branch string
+	commit *github.RepositoryCommit
+}
+
+func NewServer() *Server {
+	server := &Server{repos: make(map[string]*Repo)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/{owner}/{repo}/branches/{branch}", server.getBranch)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/branches/{branch}/protection/required_status_checks", server.getRequiredStatusChecks)
+	// The ref may contain slashes, e.g. auto-upgrade/v1.17.0, so it can't be matched by a single segment.
+	mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{path...}", server.routeCommit)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", server.getRepo)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}", server.editRepo)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/compare/{basehead}", server.compareCommits)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls", server.listPulls)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/pulls", server.createPull)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}", server.getPull)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/pulls/{number}", server.editPull)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/files", server.listPullFiles)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/pulls/{number}/merge", server.mergePull)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/releases", server.listReleases)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/releases/tags/{tag}", server.getReleaseByTag)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/releases", server.createRelease)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/releases/{id}", server.deleteRelease)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/releases/generate-notes", server.generateNotes)
+	// The ref may contain slashes as well, e.g. heads/auto-upgrade/v1.17.0.
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/git/refs/{ref...}", server.deleteRef)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/tags", server.listTags)
+
+	server.Server = httptest.NewServer(mux)
+
+	return server
+}
+
+// AddRepo adds a repo with the master branch as the default branch, the branches are created as well.
+func (r *Server) AddRepo(owner, name string, branches ...string) *Repo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := &Repo{
+		Owner:            owner,
+		Name:             name,
+		DefaultBranch:    "master",
+		Branches:         append([]string{"master"}, branches...),
+		tagCommits:       make(map[string]int),
+		statuses:         make(map[string][]*github.RepoStatus),
+		checkRuns:        make(map[string][]*github.CheckRun),
+		pendingCheckRuns: make(map[*github.CheckRun]*pendingCheckRun),
+		requiredChecks:   make(map[string][]string),
+		pullFiles:        make(map[int][]*github.CommitFile),
+	}
+	r.repos[owner+"/"+name] = repo
+
+	return repo
+}
+
+// AddRelease adds a published release and its tag to the repo, the release is the newest one.
+func (r *Server) AddRelease(owner, name, tag string, prerelease bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repos[owner+"/"+name]
+	repo.Releases = slices.Insert(repo.Releases, 0, &github.RepositoryRelease{
+		ID:         convert.Pointer(repo.nextReleaseID()),
+		TagName:    convert.Pointer(tag),
+		Name:       convert.Pointer(tag),
+		Prerelease: convert.Pointer(prerelease),
+		Draft:      convert.Pointer(false),
+	})
+	repo.Tags = slices.Insert(repo.Tags, 0, tag)
+	repo.tagCommits[tag] = len(repo.commits)
+}
+
+// AddCommit pushes a commit to the branch directly, e.g. a commit of a bot.
+func (r *Server) AddCommit(owner, name, branch, message, author string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.repos[owner+"/"+name].addCommit(branch, message, author)
+}
+
+// AddPull opens a pull request by @goravel, it returns the pull request number.
+func (r *Server) AddPull(owner, name, title, head, base string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.repos[owner+"/"+name].addPull(title, head, base).GetNumber()
+}
+
+// MergePull squashes and merges the pull request into its base branch, it's what a maintainer does while the release is waiting.
+func (r *Server) MergePull(owner, name string, number int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.repos[owner+"/"+name].mergePull(number)
+}
+
+// AddPullFile adds a file changed by the pull request.
+func (r *Server) AddPullFile(owner, name string, number int, filename string, additions, deletions int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repos[owner+"/"+name]
+	repo.pullFiles[number] = append(repo.pullFiles[number], &github.CommitFile{
+		Filename:  convert.Pointer(filename),
+		Status:    convert.Pointer("modified"),
+		Additions: convert.Pointer(additions),
+		Deletions: convert.Pointer(deletions),
+		Changes:   convert.Pointer(additions + deletions),
+	})
+}
+
+// AddStatus sets the commit status of the context at the ref, e.g. a branch, the state is success, pending, failure or error.
+func (r *Server) AddStatus(owner, name, ref, context, state string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repos[owner+"/"+name]
+	repo.statuses[ref] = slices.DeleteFunc(repo.statuses[ref], func(status *github.RepoStatus) bool {
+		return status.GetContext() == context
+	})
+	repo.statuses[ref] = append(repo.statuses[ref], &github.RepoStatus{
+		Context:   convert.Pointer(context),
+		State:     convert.Pointer(state),
+		TargetURL: convert.Pointer(fmt.Sprintf("https://ci.goravel.dev/%s/%s/%s", owner, name, context)),
+	})
+}
+
+// AddCheckRun sets the check run of the name at the ref, the status is queued, in_progress or completed, and the
+// conclusion is only set once it's completed, e.g. success or failure.
+func (r *Server) AddCheckRun(owner, name, ref, checkName, status, conclusion string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repos[owner+"/"+name]
+	repo.checkRuns[ref] = slices.DeleteFunc(repo.checkRuns[ref], func(checkRun *github.CheckRun) bool {
+		return checkRun.GetName() == checkName
+	})
+
+	checkRun := &github.CheckRun{
+		ID:      convert.Pointer(int64(len(repo.checkRuns[ref]) + 1)),
+		Name:    convert.Pointer(checkName),
+		Status:  convert.Pointer(status),
+		HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/runs/%s", owner, name, checkName)),
+	}
+	if conclusion != "" {
+		checkRun.Conclusion = convert.Pointer(conclusion)
+	}
+	repo.checkRuns[ref] = append(repo.checkRuns[ref], checkRun)
+}
+
+// AddPendingCheckRun adds a check run in progress for the first polls lists of the ref, then it's completed with the
+// conclusion, e.g. the CI triggered by merging a PR.
+func (r *Server) AddPendingCheckRun(owner, name, ref, checkName string, polls int, conclusion string) {
+	r.AddCheckRun(owner, name, ref, checkName, "in_progress", "")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repos[owner+"/"+name]
+	checkRuns := repo.checkRuns[ref]
+	repo.pendingCheckRuns[checkRuns[len(checkRuns)-1]] = &pendingCheckRun{conclusion: conclusion, polls: polls}
+}
+
+// SetRequiredChecks protects the branch and requires the checks to pass.
+func (r *Server) SetRequiredChecks(owner, name, branch string, checks ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.repos[owner+"/"+name].requiredChecks[branch] = checks
+}
+
+// Repo returns a snapshot of the repo state.
+func (r *Server) Repo(owner, name string) *Repo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := *r.repos[owner+"/"+name]
+	repo.Branches = slices.Clone(repo.Branches)
+	repo.Tags = slices.Clone(repo.Tags)
+	repo.Releases = slices.Clone(repo.Releases)
+	repo.Pulls = slices.Clone(repo.Pulls)
+	repo.MergeMethods = slices.Clone(repo.MergeMethods)
+
+	return &repo
+}
+
+// compareCommits returns the commits of the head branch created after the base tag.
+func (r *Server) compareCommits(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		base, head, _ := strings.Cut(req.PathValue("basehead"), "...")
+		start, exist := repo.tagCommits[base]
+		if !exist || !slices.Contains(repo.Branches, head) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		var commits []*github.RepositoryCommit
+		for _, commit := range repo.commits[start:] {
+			if commit.branch == head {
+				commits = append(commits, commit.commit)
+			}
+		}
+
+		// The commits are paged as well, the pagination of the compare API applies to them.
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		page = max(page, 1)
+		perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+		if perPage <= 0 {
+			perPage = 250
+		}
+
+		start = min((page-1)*perPage, len(commits))
+		end := min(start+perPage, len(commits))
+		setNextLink(w, req, page, end < len(commits))
+
+		writeJSON(w, http.StatusOK, &github.CommitsComparison{
+			TotalCommits: convert.Pointer(len(commits)),
+			Commits:      commits[start:end],
+		})
+	})
+}
+
+func (r *Server) createPull(w http.ResponseWriter, req *http.Request) {
+	var newPull github.NewPullRequest
+	if !decode(w, req, &newPull) {
+		return
+	}
+
+	r.withRepo(w, req, func(repo *Repo) {
+		// The head branch is pushed via git, which the server doesn't track, so only the base branch is checked.
+		if !slices.Contains(repo.Branches, newPull.GetBase()) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, repo.addPull(newPull.GetTitle(), newPull.GetHead(), newPull.GetBase()))
+	})
+}
+
+func (r *Server) createRelease(w http.ResponseWriter, req *http.Request) {
+	var release github.RepositoryRelease
+	if !decode(w, req, &release) {
+		return
+	}
+
+	r.withRepo(w, req, func(repo *Repo) {
+		if slices.ContainsFunc(repo.Releases, func(existing *github.RepositoryRelease) bool {
+			return existing.GetTagName() == release.GetTagName()
+		}) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+		if !slices.Contains(repo.Branches, release.GetTargetCommitish()) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+
+		release.ID = convert.Pointer(repo.nextReleaseID())
+		release.Draft = convert.Pointer(release.GetDraft())
+		release.Prerelease = convert.Pointer(release.GetPrerelease())
+		release.HTMLURL = convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", repo.Owner, repo.Name, release.GetTagName()))
+		repo.Releases = slices.Insert(repo.Releases, 0, &release)
+		if !slices.Contains(repo.Tags, release.GetTagName()) {
+			repo.Tags = slices.Insert(repo.Tags, 0, release.GetTagName())
+		}
+
+		writeJSON(w, http.StatusCreated, &release)
+	})
+}
+
+// deleteRef deletes a tag or a branch, the ref is like tags/v1.17.0 or heads/v1.17.x.
+func (r *Server) deleteRef(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		var refs *[]string
+		ref := req.PathValue("ref")
+		switch {
+		case strings.HasPrefix(ref, "tags/"):
+			refs, ref = &repo.Tags, strings.TrimPrefix(ref, "tags/")
+		case strings.HasPrefix(ref, "heads/"):
+			refs, ref = &repo.Branches, strings.TrimPrefix(ref, "heads/")
+		}
+		if refs == nil || !slices.Contains(*refs, ref) {
+			writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+			return
+		}
+
+		*refs = slices.DeleteFunc(*refs, func(existing string) bool {
+			return existing == ref
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// deleteRelease deletes the release only, the tag is kept as GitHub does.
+func (r *Server) deleteRelease(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		id, err := strconv.ParseInt(req.PathValue("id"), 10, 64)
+		index := slices.IndexFunc(repo.Releases, func(release *github.RepositoryRelease) bool {
+			return release.GetID() == id
+		})
+		if err != nil || index < 0 {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		repo.Releases = slices.Delete(repo.Releases, index, index+1)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// editPull supports closing the pull request only.
+func (r *Server) editPull(w http.ResponseWriter, req *http.Request) {
+	var edit github.PullRequest
+	if !decode(w, req, &edit) {
+		return
+	}
+
+	r.withRepo(w, req, func(repo *Repo) {
+		number, err := strconv.Atoi(req.PathValue("number"))
+		if err != nil || number < 1 || number > len(repo.Pulls) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		pull := repo.Pulls[number-1]
+		if edit.GetState() == "closed" && !pull.GetMerged() {
+			pull.State = convert.Pointer("closed")
+		}
+
+		writeJSON(w, http.StatusOK, pull)
+	})
+}
+
+func (r *Server) editRepo(w http.ResponseWriter, req *http.Request) {
+	var edit github.Repository
+	if !decode(w, req, &edit) {
+		return
+	}
+
+	r.withRepo(w, req, func(repo *Repo) {
+		if edit.DefaultBranch != nil {
+			if !slices.Contains(repo.Branches, edit.GetDefaultBranch()) {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+				return
+			}
+
+			repo.DefaultBranch = edit.GetDefaultBranch()
+		}
+
+		writeJSON(w, http.StatusOK, &github.Repository{
+			Name:          convert.Pointer(repo.Name),
+			DefaultBranch: convert.Pointer(repo.DefaultBranch),
+		})
+	})
+}
+
+func (r *Server) generateNotes(w http.ResponseWriter, req *http.Request) {
+	var opts github.GenerateNotesOptions
+	if !decode(w, req, &opts) {
+		return
+	}
+
+	r.withRepo(w, req, func(repo *Repo) {
+		if opts.TargetCommitish != nil && !slices.Contains(repo.Branches, opts.GetTargetCommitish()) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+
+		body := "## What's Changed"
+		for _, pull := range repo.Pulls {
+			if pull.GetMerged() && (opts.TargetCommitish == nil || pull.GetBase().GetRef() == opts.GetTargetCommitish()) {
+				body += fmt.Sprintf("\n* %s by @%s in %s", pull.GetTitle(), pull.GetUser().GetLogin(), pull.GetHTMLURL())
+			}
+		}
+		if opts.GetPreviousTagName() != "" {
+			body += fmt.Sprintf("\n\n**Full Changelog**: https://github.com/%s/%s/compare/%s...%s", repo.Owner, repo.Name, opts.GetPreviousTagName(), opts.TagName)
+		}
+
+		writeJSON(w, http.StatusOK, &github.RepositoryReleaseNotes{
+			Name: opts.TagName,
+			Body: body,
+		})
+	})
+}
+
+func (r *Server) getBranch(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		branch := req.PathValue("branch")
+		if !slices.Contains(repo.Branches, branch) {
+			writeError(w, http.StatusNotFound, "Branch not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, &github.Branch{Name: convert.Pointer(branch)})
+	})
+}
+
+// getCombinedStatus returns all statuses at the ref in one page, the combined state is failure if any status failed,
+// pending if any is pending or there is none, success otherwise.
+func (r *Server) getCombinedStatus(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		ref := strings.TrimSuffix(req.PathValue("path"), "/status")
+		statuses := repo.statuses[ref]
+
+		state := "success"
+		for _, status := range statuses {
+			switch status.GetState() {
+			case "failure", "error":
+				state = "failure"
+			case "pending":
+				if state != "failure" {
+					state = "pending"
+				}
+			}
+		}
+		if len(statuses) == 0 {
+			state = "pending"
+		}
+
+		writeJSON(w, http.StatusOK, &github.CombinedStatus{
+			State:      convert.Pointer(state),
+			TotalCount: convert.Pointer(len(statuses)),
+			Statuses:   statuses,
+		})
+	})
+}
+
+func (r *Server) getPull(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		number, err := strconv.Atoi(req.PathValue("number"))
+		if err != nil || number < 1 || number > len(repo.Pulls) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, repo.Pulls[number-1])
+	})
+}
+
+func (r *Server) getReleaseByTag(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		index := slices.IndexFunc(repo.Releases, func(release *github.RepositoryRelease) bool {
+			return release.GetTagName() == req.PathValue("tag") && !release.GetDraft()
+		})
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, repo.Releases[index])
+	})
+}
+
+func (r *Server) getRepo(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		writeJSON(w, http.StatusOK, &github.Repository{
+			Name:          convert.Pointer(repo.Name),
+			DefaultBranch: convert.Pointer(repo.DefaultBranch),
+		})
+	})
+}
+
+func (r *Server) getRequiredStatusChecks(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		checks, exist := repo.requiredChecks[req.PathValue("branch")]
+		if !exist {
+			writeError(w, http.StatusNotFound, "Branch not protected")
+			return
+		}
+
+		var requiredChecks []*github.RequiredStatusCheck
+		for _, check := range checks {
+			requiredChecks = append(requiredChecks, &github.RequiredStatusCheck{Context: check})
+		}
+
+		writeJSON(w, http.StatusOK, &github.RequiredStatusChecks{Strict: true, Checks: &requiredChecks})
+	})
+}
+
+func (r *Server) listCheckRuns(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		checkRuns := repo.checkRuns[strings.TrimSuffix(req.PathValue("path"), "/check-runs")]
+		for _, checkRun := range checkRuns {
+			pending, exist := repo.pendingCheckRuns[checkRun]
+			if !exist {
+				continue
+			}
+
+			if pending.polls--; pending.polls < 0 {
+				checkRun.Status = convert.Pointer("completed")
+				checkRun.Conclusion = convert.Pointer(pending.conclusion)
+				delete(repo.pendingCheckRuns, checkRun)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, &github.ListCheckRunsResults{
+			Total:     convert.Pointer(len(checkRuns)),
+			CheckRuns: checkRuns,
+		})
+	})
+}
+
+func (r *Server) listPullFiles(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		number, err := strconv.Atoi(req.PathValue("number"))
+		if err != nil || number < 1 || number > len(repo.Pulls) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		writePage(w, req, repo.pullFiles[number])
+	})
+}
+
+func (r *Server) listPulls(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		state := req.URL.Query().Get("state")
+		base := req.URL.Query().Get("base")
+
+		var pulls []*github.PullRequest
+		for _, pull := range slices.Backward(repo.Pulls) {
+			if (state == "" || state == "all" || pull.GetState() == state) && (base == "" || pull.GetBase().GetRef() == base) {
+				pulls = append(pulls, pull)
+			}
+		}
+
+		writePage(w, req, pulls)
+	})
+}
+
+func (r *Server) listReleases(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		writePage(w, req, repo.Releases)
+	})
+}
+
+func (r *Server) listTags(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		var tags []*github.RepositoryTag
+		for _, tag := range repo.Tags {
+			tags = append(tags, &github.RepositoryTag{Name: convert.Pointer(tag)})
+		}
+
+		writePage(w, req, tags)
+	})
+}
+
+func (r *Server) mergePull(w http.ResponseWriter, req *http.Request) {
+	r.withRepo(w, req, func(repo *Repo) {
+		number, err := strconv.Atoi(req.PathValue("number"))
+		if err != nil || number < 1 || number > len(repo.Pulls) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		if repo.Pulls[number-1].GetState() != "open" {
+			writeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+			return
+		}
+
+		var options struct {
+			MergeMethod string `json:"merge_method"`
+		}
+		if !decode(w, req, &options) {
+			return
+		}
+
+		repo.mergePull(number)
+		repo.MergeMethods = append(repo.MergeMethods, options.MergeMethod)
+
+		writeJSON(w, http.StatusOK, &github.PullRequestMergeResult{
+			Merged:  convert.Pointer(true),
+			Message: convert.Pointer("Pull Request successfully merged"),
+		})
+	})
+}
+
+// routeCommit routes the requests of a commit ref, e.g. /commits/{ref}/status.
+func (r *Server) routeCommit(w http.ResponseWriter, req *http.Request) {
+	switch path := req.PathValue("path"); {
+	case strings.HasSuffix(path, "/check-runs"):
+		r.listCheckRuns(w, req)
+	case strings.HasSuffix(path, "/status"):
+		r.getCombinedStatus(w, req)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (r *Repo) addPull(title, head, base string) *github.PullRequest {
+	number := len(r.Pulls) + 1
+	pull := &github.PullRequest{
+		Number:  convert.Pointer(number),
+		Title:   convert.Pointer(title),
+		State:   convert.Pointer("open"),
+		Merged:  convert.Pointer(false),
+		HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/pull/%d", r.Owner, r.Name, number)),
+		User:    &github.User{Login: convert.Pointer("goravel")},
+		Head:    &github.PullRequestBranch{Ref: convert.Pointer(head)},
+		Base:    &github.PullRequestBranch{Ref: convert.Pointer(base)},
+	}
+	r.Pulls = append(r.Pulls, pull)
+
+	return pull
+}
+
+// mergePull squashes the pull request into its base branch.
+func (r *Repo) mergePull(number int) {
+	pull := r.Pulls[number-1]
+	pull.State = convert.Pointer("closed")
+	pull.Merged = convert.Pointer(true)
+	r.addCommit(pull.GetBase().GetRef(), fmt.Sprintf("%s (#%d)", pull.GetTitle(), number), pull.GetUser().GetLogin())
+}
+
+// nextReleaseID returns an ID greater than the existing ones, so the IDs stay unique after the releases are deleted.
+func (r *Repo) nextReleaseID() int64 {
+	var id int64
+	for _, release := range r.Releases {
+		id = max(id, release.GetID())
+	}
+
+	return id + 1
+}
+
+func (r *Repo) addCommit(branch, message, author string) {
+	sha := fmt.Sprintf("%040x", len(r.commits)+1)
+	r.commits = append(r.commits, &commit{
+		branch: branch,
+		commit: &github.RepositoryCommit{
+			SHA:     convert.Pointer(sha),
+			HTMLURL: convert.Pointer(fmt.Sprintf("https://github.com/%s/%s/commit/%s", r.Owner, r.Name, sha)),
+			Author:  &github.User{Login: convert.Pointer(author)},
+			Commit:  &github.Commit{Message: convert.Pointer(message)},
+		},
+	})
+}
+
+// withRepo runs the handler against the repo in the path with the state locked, 404 is returned if it doesn't exist.
+func (r *Server) withRepo(w http.ResponseWriter, req *http.Request, handler func(repo *Repo)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo, exist := r.repos[req.PathValue("owner")+"/"+req.PathValue("repo")]
+	if !exist {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	handler(repo)
+}
+
+func decode(w http.ResponseWriter, req *http.Request, value any) bool {
+	if err := json.NewDecoder(req.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+
+	return true
+}
+
+// writePage writes a page of the items following the page and per_page query, the Link header points to the next page.
+func writePage[T any](w http.ResponseWriter, req *http.Request, items []T) {
+	page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	page = max(page, 1)
+	perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = 30
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	setNextLink(w, req, page, end < len(items))
+
+	writeJSON(w, http.StatusOK, append([]T{}, items[start:end]...))
+}
+
+// setNextLink sets the Link header pointing to the next page if there is one.
+func setNextLink(w http.ResponseWriter, req *http.Request, page int, hasNext bool) {
+	if !hasNext {
+		return
+	}
+
+	next := *req.URL
+	query := next.Query()
+	query.Set("page", strconv.Itoa(page+1))
+	next.RawQuery = query.Encode()
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,252 @@
+// Package gitfixture creates bare git repos and a local GOPROXY directory, so the clone, go get, tidy, commit
+// and push path of the release can be tested against real git commits without network.
+package gitfixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/zip"
+)
+
+type Fixtures struct {
+	t testing.TB
+	// The dir of the bare repos, a repo is at <dir>/<owner>/<repo>.git
+	dir string
+	// The dir of the module proxy, it's used as GOPROXY
+	proxy string
+}
+
+// New creates the fixtures in temp dirs and points the go and git commands of the test to them:
+// the modules are resolved from the local proxy only, and the commits are made by a fixed identity.
+func New(t testing.TB) *Fixtures {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	fixtures := &Fixtures{
+		t:     t,
+		dir:   t.TempDir(),
+		proxy: t.TempDir(),
+	}
+
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(fixtures.proxy))
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOFLAGS", "-modcacherw")
+	t.Setenv("GOMODCACHE", t.TempDir())
+	t.Setenv("GOTOOLCHAIN", "local")
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(key, "Goravel")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(key, "release@goravel.dev")
+	}
+
+	return fixtures
+}
+
+// CloneURL returns the clone URL template of the bare repos, it's used as release.clone_url.
+func (r *Fixtures) CloneURL() string {
+	return "file://" + filepath.ToSlash(r.dir) + "/%s/%s.git"
+}
+
+// AddRepo creates a bare repo with the files committed on master, the branches are created from master.
+func (r *Fixtures) AddRepo(owner, name string, files map[string]string, branches ...string) {
+	r.t.Helper()
+
+	bare := r.repoDir(owner, name)
+	work := r.t.TempDir()
+	writeFiles(r.t, work, files)
+
+	r.git("", "init", "--bare", "--initial-branch=master", bare)
+	r.git(work, "init", "--initial-branch=master")
+	r.git(work, "add", "--all")
+	r.git(work, "commit", "--message", "Initial commit")
+	r.git(work, "remote", "add", "origin", bare)
+	r.git(work, "push", "origin", "master")
+	for _, branch := range branches {
+		r.git(work, "push", "origin", "master:"+branch)
+	}
+}
+
+// AddCommit commits the files on the branch of the bare repo, a file with empty content is removed.
+func (r *Fixtures) AddCommit(owner, name, branch, message string, files map[string]string) {
+	r.t.Helper()
+
+	work := r.t.TempDir()
+	r.git(work, "clone", "--branch", branch, r.repoDir(owner, name), ".")
+	written := make(map[string]string)
+	for path, content := range files {
+		if content == "" {
+			r.git(work, "rm", "--quiet", path)
+			continue
+		}
+
+		written[path] = content
+	}
+	writeFiles(r.t, work, written)
+	r.git(work, "add", "--all")
+	r.git(work, "commit", "--message", message)
+	r.git(work, "push", "origin", branch)
+}
+
+// AddTag tags the head of the branch in the bare repo.
+func (r *Fixtures) AddTag(owner, name, tag, branch string) {
+	r.t.Helper()
+
+	r.git(r.repoDir(owner, name), "tag", tag, branch)
+}
+
+// AddModule publishes the files as the version of the module to the proxy, the files should contain go.mod.
+func (r *Fixtures) AddModule(path, version string, files map[string]string) {
+	r.t.Helper()
+
+	src := r.t.TempDir()
+	writeFiles(r.t, src, files)
+
+	escaped, err := module.EscapePath(path)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	dir := filepath.Join(r.proxy, filepath.FromSlash(escaped), "@v")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		r.t.Fatal(err)
+	}
+
+	archive, err := os.Create(filepath.Join(dir, version+".zip"))
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	if err := zip.CreateFromDir(archive, module.Version{Path: path, Version: version}, src); err != nil {
+		r.t.Fatal(err)
+	}
+
+	info, err := json.Marshal(map[string]any{"Version": version, "Time": time.Now().UTC()})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	list, err := os.OpenFile(filepath.Join(dir, "list"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	defer func() {
+		_ = list.Close()
+	}()
+
+	writeFiles(r.t, dir, map[string]string{
+		version + ".info": string(info),
+		version + ".mod":  files["go.mod"],
+	})
+	if _, err := list.WriteString(version + "\n"); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// ServeProxy serves the proxy dir over HTTP along with a checksum database, whose lookups return the go.sum lines
+// of the published modules, so the modules can be verified the same way as proxy.golang.org and sum.golang.org.
+func (r *Fixtures) ServeProxy() *httptest.Server {
+	files := http.FileServer(http.Dir(r.proxy))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lookup, ok := strings.CutPrefix(req.URL.Path, "/lookup/")
+		if !ok {
+			files.ServeHTTP(w, req)
+			return
+		}
+
+		escapedPath, version, _ := strings.Cut(lookup, "@")
+		path, err := module.UnescapePath(escapedPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		dir := filepath.Join(r.proxy, filepath.FromSlash(escapedPath), "@v")
+		zipHash, err := dirhash.HashZip(filepath.Join(dir, version+".zip"), dirhash.Hash1)
+		if err != nil {
+			http.NotFound(w, req)
+			return
+		}
+		goModHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return os.Open(filepath.Join(dir, version+".mod"))
+		})
+		if err != nil {
+			http.NotFound(w, req)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "1\n%s %s %s\n%s %s/go.mod %s\n\ngo.sum database tree\n", path, version, zipHash, path, version, goModHash)
+	}))
+	r.t.Cleanup(server.Close)
+
+	return server
+}
+
+// Branches returns the branches of the bare repo.
+func (r *Fixtures) Branches(owner, name string) []string {
+	r.t.Helper()
+
+	return strings.Fields(r.git(r.repoDir(owner, name), "branch", "--format=%(refname:short)"))
+}
+
+// Commits returns the commit subjects of the branch in the bare repo, the newest first.
+func (r *Fixtures) Commits(owner, name, branch string) []string {
+	r.t.Helper()
+
+	return strings.Split(r.git(r.repoDir(owner, name), "log", "--format=%s", branch), "\n")
+}
+
+// File returns the content of the file on the branch in the bare repo.
+func (r *Fixtures) File(owner, name, branch, path string) string {
+	r.t.Helper()
+
+	return r.git(r.repoDir(owner, name), "show", branch+":"+path)
+}
+
+func (r *Fixtures) git(dir string, args ...string) string {
+	r.t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("failed to run git %s: %v: %s", strings.Join(args, " "), err, output)
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+func (r *Fixtures) repoDir(owner, name string) string {
+	return filepath.Join(r.dir, owner, name+".git")
+}
+
+func writeFiles(t testing.TB, dir string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
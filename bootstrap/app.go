@@ -15,9 +15,17 @@ func Boot() contractsfoundation.Application {
 		WithConfig(config.Boot).
 		WithCommands(func() []console.Command {
 			return []console.Command{
+				commands.NewApply(),
+				commands.NewChangelog(),
 				commands.NewMajor(),
+				commands.NewNext(),
 				commands.NewPatch(),
+				commands.NewPlan(),
 				commands.NewPreview(),
+				commands.NewRC(),
+				commands.NewRollback(),
+				commands.NewSmoke(),
+				commands.NewVerify(),
 			}
 		}).
 		Create()
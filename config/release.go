@@ -0,0 +1,51 @@
+package config
+
+import (
+	"goravel/app/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("release", map[string]any{
+		// Release Manifest
+		//
+		// The YAML or JSON file that declares all repositories taking part in a release,
+		// their owners, roles, dependencies and branch policies.
+		"manifest": config.Env("RELEASE_MANIFEST", "release.yaml"),
+
+		// GitHub API URL
+		//
+		// The base URL of the GitHub REST API, it can point to GitHub Enterprise
+		// or a local stand-in when testing the release end to end.
+		"github_url": config.Env("GITHUB_API_URL", "https://api.github.com/"),
+
+		// Clone URL
+		//
+		// The URL template to clone the repos, the owner and the repo names replace the two %s
+		// in order, a single %s is replaced by owner/repo, e.g. file:///tmp/fixtures/%s.git.
+		"clone_url": config.Env("RELEASE_CLONE_URL", "git@github.com:%s/%s.git"),
+
+		// Go Module Proxy URL
+		//
+		// The base URL of the Go module proxy the released modules are verified against,
+		// it can point to a local stand-in when testing the release end to end.
+		"proxy_url": config.Env("RELEASE_PROXY_URL", "https://proxy.golang.org/"),
+
+		// Checksum Database URL
+		//
+		// The base URL of the checksum database the hashes of the released modules are checked against.
+		"sumdb_url": config.Env("RELEASE_SUMDB_URL", "https://sum.golang.org/"),
+
+		// Audit Log
+		//
+		// The JSONL file every mutating action of a real release is appended to, including the GitHub API
+		// requests and the git pushes, storage/release/audit.jsonl if it's empty.
+		"audit_log": config.Env("RELEASE_AUDIT_LOG", ""),
+
+		// Operator
+		//
+		// Who performs the release in the audit log, e.g. the GitHub login of the maintainer,
+		// the current OS user if it's empty.
+		"operator": config.Env("RELEASE_OPERATOR", ""),
+	})
+}